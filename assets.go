@@ -29,6 +29,25 @@ type Asset struct {
 	Status          string    `json:"status"`
 	InstitutionName string    `json:"institution_name"`
 	CreatedAt       time.Time `json:"created_at"`
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Asset) UnmarshalJSON(data []byte) error {
+	type plain Asset
+	extra, err := unmarshalExtra(data, (*plain)(a))
+	a.Extra = extra
+
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a Asset) MarshalJSON() ([]byte, error) {
+	type plain Asset
+
+	return marshalExtra(plain(a), a.Extra)
 }
 
 // ParsedAmount converts the asset's balance and currency into a money.Money object.
@@ -38,30 +57,36 @@ func (a *Asset) ParsedAmount() (*money.Money, error) {
 	return ParseCurrency(a.Balance, a.Currency)
 }
 
-// GetAssets retrieves all assets from the Lunch Money API.
+// List retrieves all assets from the Lunch Money API.
 // It returns a slice of Asset objects containing information about each asset,
 // including balance, institution, and status details. Returns an error if the request fails.
-func (c *Client) GetAssets(ctx context.Context) ([]*Asset, error) {
-	validate := validator.New()
+func (s *assetService) List(ctx context.Context) ([]*Asset, error) {
 	options := map[string]string{}
 
-	body, err := c.Get(ctx, "/v1/assets", options)
+	body, err := s.c.Get(ctx, "/v1/assets", options)
 	if err != nil {
 		return nil, fmt.Errorf("get assets: %w", err)
 	}
 
 	resp := &AssetsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/assets", body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	if err := validate.Struct(resp); err != nil {
+	if err := s.c.checkResponse(ctx, "/v1/assets", resp); err != nil {
 		return nil, err
 	}
 
 	return resp.Assets, nil
 }
 
+// GetAssets retrieves all assets.
+//
+// Deprecated: Use Client.Assets.List.
+func (c *Client) GetAssets(ctx context.Context) ([]*Asset, error) {
+	return c.AssetService().List(ctx)
+}
+
 // UpdateAsset contains the fields that can be updated for an existing asset.
 // Only non-nil fields will be sent in the update request.
 type UpdateAsset struct {
@@ -77,24 +102,32 @@ type UpdateAsset struct {
 	ExcludedTransactions *bool   `json:"excluded_transactions,omitempty"`
 }
 
-// UpdateAsset modifies an existing asset with the specified ID using the provided fields.
+// Update modifies an existing asset with the specified ID using the provided fields.
 // It returns the updated asset information or an error if the update fails.
 // Only fields that are non-nil in the asset parameter will be updated.
-func (c *Client) UpdateAsset(ctx context.Context, id int64, asset *UpdateAsset) (*Asset, error) {
+func (s *assetService) Update(ctx context.Context, id int64, asset *UpdateAsset) (*Asset, error) {
 	validate := validator.New()
 	if err := validate.Struct(asset); err != nil {
 		return nil, err
 	}
 
-	body, err := c.Put(ctx, fmt.Sprintf("/v1/assets/%d", id), asset)
+	path := fmt.Sprintf("/v1/assets/%d", id)
+	body, err := s.c.Put(ctx, path, asset)
 	if err != nil {
 		return nil, fmt.Errorf("put asset %d: %w", id, err)
 	}
 
 	resp := &Asset{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, path, body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
 	return resp, nil
 }
+
+// UpdateAsset modifies an existing asset.
+//
+// Deprecated: Use Client.Assets.Update.
+func (c *Client) UpdateAsset(ctx context.Context, id int64, asset *UpdateAsset) (*Asset, error) {
+	return c.AssetService().Update(ctx, id, asset)
+}
@@ -1,12 +1,9 @@
 package lunchmoney
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 
 	"github.com/Rhymond/go-money"
 	"github.com/go-playground/validator/v10"
@@ -34,6 +31,25 @@ type Budget struct {
 			ToBase   float64 `json:"to_base"`
 		} `json:"list"`
 	} `json:"recurring,omitempty"`
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Budget) UnmarshalJSON(data []byte) error {
+	type plain Budget
+	extra, err := unmarshalExtra(data, (*plain)(b))
+	b.Extra = extra
+
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Budget) MarshalJSON() ([]byte, error) {
+	type plain Budget
+
+	return marshalExtra(plain(b), b.Extra)
 }
 
 // BudgetData is a single month's budget for a category.
@@ -76,8 +92,8 @@ func (b *BudgetData) ParsedAmount() (*money.Money, error) {
 	return ParseCurrency(b.BudgetAmount.String(), b.BudgetCurrency)
 }
 
-// GetBudgets returns budgets within a time period.
-func (c *Client) GetBudgets(ctx context.Context, filters *BudgetFilters) ([]*Budget, error) {
+// List returns budgets within a time period.
+func (s *budgetService) List(ctx context.Context, filters *BudgetFilters) ([]*Budget, error) {
 	validate := validator.New()
 	options := map[string]string{}
 	if filters != nil {
@@ -92,40 +108,41 @@ func (c *Client) GetBudgets(ctx context.Context, filters *BudgetFilters) ([]*Bud
 		options = maps
 	}
 
-	body, err := c.Get(ctx, "/v1/budgets", options)
+	body, err := s.c.Get(ctx, "/v1/budgets", options)
 	if err != nil {
 		return nil, fmt.Errorf("get budgets: %w", err)
 	}
 
 	var resp []*Budget
-	var bodyCopy bytes.Buffer
-	tee := io.TeeReader(body, &bodyCopy)
-	if err := json.NewDecoder(tee).Decode(&resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/budgets", body, &resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
 	for _, b := range resp {
-		// Clean up sometimes bad data returned.
-		for k, bd := range b.Data {
-			if bd.BudgetMonth == "" {
-				bd.BudgetMonth = k
+		// The API sometimes leaves budget_month empty. Strict mode reports
+		// it; the others fill it in from the map key.
+		if s.c.Decoding != DecodeStrict {
+			for k, bd := range b.Data {
+				if bd.BudgetMonth == "" {
+					bd.BudgetMonth = k
+					if s.c.Decoding == DecodeLenient {
+						s.c.warn(ctx, "/v1/budgets", fmt.Errorf("budget %q: empty budget_month for %s", b.CategoryName, k))
+					}
+				}
 			}
 		}
 
-		if err := validate.StructCtx(ctx, b); err != nil {
-			var validationErrors validator.ValidationErrors
-			var invalidValidationError *validator.InvalidValidationError
-
-			switch {
-			case errors.As(err, &validationErrors):
-				return nil, fmt.Errorf("validating response: %s", validationErrors.Error())
-			case errors.As(err, &invalidValidationError):
-				return nil, fmt.Errorf("validating response (InvalidValidation): %s", invalidValidationError.Error())
-			default:
-				return nil, fmt.Errorf("validating response (%T): %w", err, err)
-			}
+		if err := s.c.checkResponse(ctx, "/v1/budgets", b); err != nil {
+			return nil, err
 		}
 	}
 
 	return resp, nil
 }
+
+// GetBudgets returns budgets within a time period.
+//
+// Deprecated: Use Client.Budgets.List.
+func (c *Client) GetBudgets(ctx context.Context, filters *BudgetFilters) ([]*Budget, error) {
+	return c.BudgetService().List(ctx, filters)
+}
@@ -0,0 +1,126 @@
+package lunchmoney
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BulkUpdateOptions configure BulkUpdate.
+type BulkUpdateOptions struct {
+	// Concurrency is how many updates run at once. Defaults to 4.
+	Concurrency int
+	// Update holds request level options sent with every update.
+	Update *UpdateTransactionOptions
+}
+
+// BulkUpdateResult is the outcome of updating a single transaction.
+type BulkUpdateResult struct {
+	ID       int64
+	Response *UpdateTransactionResp
+	Err      error
+}
+
+// BulkUpdateError lists the transactions that failed to update.
+type BulkUpdateError struct {
+	Failed map[int64]error
+}
+
+func (e *BulkUpdateError) Error() string {
+	ids := make([]int64, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("%d: %v", id, e.Failed[id])
+	}
+
+	return fmt.Sprintf("%d transaction updates failed: %s", len(ids), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual errors, so errors.Is and errors.As see them.
+func (e *BulkUpdateError) Unwrap() []error {
+	ret := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		ret = append(ret, err)
+	}
+
+	return ret
+}
+
+// BulkUpdate updates many transactions, keyed by transaction ID, with
+// bounded concurrency. Requests still go through the client's Limiter. A
+// failed update doesn't stop the others; every ID gets a result, and if any
+// failed a *BulkUpdateError is returned alongside the results.
+func (s *transactionService) BulkUpdate(ctx context.Context, updates map[int64]*UpdateTransaction, opts *BulkUpdateOptions) (map[int64]*BulkUpdateResult, error) {
+	concurrency := 4
+	var updateOpts *UpdateTransactionOptions
+	if opts != nil {
+		if opts.Concurrency > 0 {
+			concurrency = opts.Concurrency
+		}
+		updateOpts = opts.Update
+	}
+
+	ids := make([]int64, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[int64]*BulkUpdateResult, len(ids))
+		sem     = make(chan struct{}, concurrency)
+	)
+
+	for _, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			results[id] = &BulkUpdateResult{ID: id, Err: ctx.Err()}
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := s.UpdateWithOptions(ctx, id, updates[id], updateOpts)
+
+			mu.Lock()
+			results[id] = &BulkUpdateResult{ID: id, Response: resp, Err: err}
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+
+	failed := map[int64]error{}
+	for id, r := range results {
+		if r.Err != nil {
+			failed[id] = r.Err
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, &BulkUpdateError{Failed: failed}
+	}
+
+	return results, nil
+}
+
+// UpdateTransactions updates many transactions with bounded concurrency.
+//
+// Deprecated: Use Client.Transactions.BulkUpdate.
+func (c *Client) UpdateTransactions(ctx context.Context, updates map[int64]*UpdateTransaction, opts *BulkUpdateOptions) (map[int64]*BulkUpdateResult, error) {
+	return c.TransactionService().BulkUpdate(ctx, updates, opts)
+}
@@ -0,0 +1,73 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateTransactions(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if r.URL.Path == "/v1/transactions/3" {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"error": "Transaction ID not found"}`))
+			require.NoError(t, err)
+			return
+		}
+
+		_, err := w.Write([]byte(`{"updated": true}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+	client.Limiter = NewRateLimiter(1000)
+
+	notes := "bulk"
+	updates := map[int64]*UpdateTransaction{}
+	for id := int64(1); id <= 10; id++ {
+		updates[id] = &UpdateTransaction{Notes: &notes}
+	}
+
+	got, err := client.UpdateTransactions(context.Background(), updates, &BulkUpdateOptions{Concurrency: 2})
+	require.Error(t, err)
+
+	var bulkErr *BulkUpdateError
+	require.True(t, errors.As(err, &bulkErr))
+	assert.Len(t, bulkErr.Failed, 1)
+	assert.Contains(t, err.Error(), "3: ")
+	assert.Contains(t, err.Error(), "Transaction ID not found")
+
+	require.Len(t, got, 10)
+	for id, r := range got {
+		if id == 3 {
+			assert.Error(t, r.Err)
+			continue
+		}
+		assert.NoError(t, r.Err)
+		assert.True(t, r.Response.Updated)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
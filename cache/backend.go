@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a cached response.
+type Entry struct {
+	Value   json.RawMessage `json:"value"`
+	Expires time.Time       `json:"expires"`
+}
+
+// Backend stores entries. Implementations must be safe for concurrent use.
+// Expired entries may be returned; the Cache checks Expires itself.
+type Backend interface {
+	// Get returns the entry for key, or nil if there isn't one.
+	Get(key string) (*Entry, error)
+	Set(key string, e *Entry) error
+	// DeletePrefix removes every entry whose key starts with prefix.
+	DeletePrefix(prefix string) error
+}
+
+// Memory is an in-memory Backend that evicts the least recently used entry
+// once it holds its maximum.
+type Memory struct {
+	size int
+
+	mu    sync.Mutex
+	order *list.List // of *memoryEntry, most recently used first
+	items map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key   string
+	entry *Entry
+}
+
+// NewMemory returns a Memory backend holding at most size entries.
+func NewMemory(size int) *Memory {
+	return &Memory{size: size, order: list.New(), items: map[string]*list.Element{}}
+}
+
+// Get implements Backend.
+func (m *Memory) Get(key string) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, nil
+	}
+	m.order.MoveToFront(el)
+
+	return el.Value.(*memoryEntry).entry, nil
+}
+
+// Set implements Backend.
+func (m *Memory) Set(key string, e *Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		el.Value.(*memoryEntry).entry = e
+		m.order.MoveToFront(el)
+		return nil
+	}
+
+	m.items[key] = m.order.PushFront(&memoryEntry{key: key, entry: e})
+	for m.size > 0 && m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryEntry).key)
+	}
+
+	return nil
+}
+
+// DeletePrefix implements Backend.
+func (m *Memory) DeletePrefix(prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, el := range m.items {
+		if strings.HasPrefix(key, prefix) {
+			m.order.Remove(el)
+			delete(m.items, key)
+		}
+	}
+
+	return nil
+}
+
+// Len returns the number of entries held.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+// Disk is a Backend storing each entry as a JSON file in a directory.
+type Disk struct {
+	dir string
+}
+
+// NewDisk returns a Disk backend in dir, creating it if needed.
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+
+	return &Disk{dir: dir}, nil
+}
+
+// path escapes key into a file name. Escaping works character by character,
+// so escaped keys share the prefixes of their keys.
+func (d *Disk) path(key string) string {
+	return filepath.Join(d.dir, url.PathEscape(key)+".json")
+}
+
+// Get implements Backend.
+func (d *Disk) Get(key string) (*Entry, error) {
+	b, err := os.ReadFile(d.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Entry{}
+	if err := json.Unmarshal(b, e); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", key, err)
+	}
+
+	return e, nil
+}
+
+// Set implements Backend. Entries are written to a temporary file and
+// renamed into place, so readers never see a partial entry.
+func (d *Disk) Set(key string, e *Entry) (err error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), d.path(key))
+}
+
+// DeletePrefix implements Backend.
+func (d *Disk) DeletePrefix(prefix string) error {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+
+	prefix = url.PathEscape(prefix)
+	var errs []error
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), prefix) && strings.HasSuffix(e.Name(), ".json") {
+			if err := os.Remove(filepath.Join(d.dir, e.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
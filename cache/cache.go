@@ -0,0 +1,300 @@
+// Package cache caches the Lunch Money resources that rarely change:
+// categories, tags, the user, assets and Plaid accounts.
+//
+//	client, err := lunchmoney.NewClient(token)
+//	...
+//	cache.New(client, nil)
+//	cats, err := client.Categories.List(ctx) // fetched
+//	cats, err = client.Categories.List(ctx)  // cached
+//
+// Each resource has its own TTL. Writes made through the client invalidate
+// the resources they can change: updating an asset drops cached assets, and
+// writing transactions drops cached tags, since tags referenced by name are
+// created, and assets, whose balances move. The API has no ETags, so changes
+// made elsewhere, such as in the web app, are only seen once the TTL expires
+// or after Invalidate.
+//
+// Entries are kept in a Backend. Memory is an in-memory LRU and Disk stores
+// entries as files, so they survive restarts. Keys are prefixed with a scope
+// naming the API key or user, so caches for different users can share a
+// Backend.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Resource is a cached kind of object.
+type Resource string
+
+// The cached resources.
+const (
+	Categories    Resource = "categories"
+	Tags          Resource = "tags"
+	User          Resource = "user"
+	Assets        Resource = "assets"
+	PlaidAccounts Resource = "plaid_accounts"
+)
+
+// DefaultTTLs are used for resources without a TTL in Options.
+var DefaultTTLs = map[Resource]time.Duration{
+	Categories:    time.Hour,
+	Tags:          time.Hour,
+	User:          24 * time.Hour,
+	Assets:        5 * time.Minute,
+	PlaidAccounts: 5 * time.Minute,
+}
+
+// Options configure a Cache.
+type Options struct {
+	// Backend stores entries. Defaults to NewMemory(256).
+	Backend Backend
+	// TTLs override DefaultTTLs per resource. A negative TTL turns caching
+	// off for the resource.
+	TTLs map[Resource]time.Duration
+	// OnError, if set, is called with backend errors. They are otherwise
+	// ignored, and the request goes to the API as if the entry was missing.
+	OnError func(error)
+	// Scope prefixes every key. Defaults to the client's TokenID, or, for a
+	// client not built with NewClient, to its user ID, fetched on first use.
+	Scope string
+}
+
+// Cache caches responses for a client. It is safe for concurrent use.
+type Cache struct {
+	// Now returns the current time. Tests can replace it.
+	Now func() time.Time
+
+	backend Backend
+	ttls    map[Resource]time.Duration
+	onError func(error)
+
+	// mu guards scope, which is looked up with user if it isn't known up
+	// front.
+	mu    sync.Mutex
+	scope string
+	user  lunchmoney.UserService
+}
+
+// New installs a cache on client, replacing its services with caching
+// versions of them. Mocks installed on client beforehand keep working, with
+// the cache in front of them.
+func New(client *lunchmoney.Client, opts *Options) *Cache {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	c := &Cache{
+		Now:     time.Now,
+		backend: opts.Backend,
+		ttls:    map[Resource]time.Duration{},
+		onError: opts.OnError,
+		scope:   opts.Scope,
+		user:    client.UserService(),
+	}
+	if c.scope == "" && client.TokenID() != "" {
+		c.scope = "token-" + client.TokenID()
+	}
+	if c.backend == nil {
+		c.backend = NewMemory(256)
+	}
+	for r, ttl := range DefaultTTLs {
+		c.ttls[r] = ttl
+	}
+	for r, ttl := range opts.TTLs {
+		c.ttls[r] = ttl
+	}
+
+	client.Categories = &categoryService{c: c, next: client.CategoryService()}
+	client.Tags = &tagService{c: c, next: client.TagService()}
+	client.User = &userService{c: c, next: client.UserService()}
+	client.Assets = &assetService{c: c, next: client.AssetService()}
+	client.Plaid = &plaidAccountService{c: c, next: client.PlaidAccountService()}
+	client.Transactions = &transactionService{c: c, next: client.TransactionService()}
+
+	return c
+}
+
+// Invalidate drops every cached entry for the resources.
+func (c *Cache) Invalidate(resources ...Resource) {
+	c.invalidate(context.Background(), resources...)
+}
+
+func (c *Cache) invalidate(ctx context.Context, resources ...Resource) {
+	scope, err := c.lookupScope(ctx)
+	if err != nil {
+		c.error(err)
+		return
+	}
+
+	for _, r := range resources {
+		if err := c.backend.DeletePrefix(scope + "/" + string(r) + "/"); err != nil {
+			c.error(fmt.Errorf("invalidate %s: %w", r, err))
+		}
+	}
+}
+
+// lookupScope returns the key prefix, fetching the user to name it if it
+// isn't known yet.
+func (c *Cache) lookupScope(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.scope == "" {
+		u, err := c.user.Get(ctx)
+		if err != nil {
+			return "", fmt.Errorf("look up cache scope: %w", err)
+		}
+		c.scope = "user-" + strconv.Itoa(u.UserID)
+	}
+
+	return c.scope, nil
+}
+
+func (c *Cache) error(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
+
+// cached returns the entry for key if it hasn't expired, and otherwise calls
+// fetch and stores the result.
+func cached[T any](ctx context.Context, c *Cache, r Resource, key string, fetch func() (T, error)) (T, error) {
+	ttl := c.ttls[r]
+	if ttl < 0 {
+		return fetch()
+	}
+
+	scope, err := c.lookupScope(ctx)
+	if err != nil {
+		c.error(err)
+		return fetch()
+	}
+
+	key = scope + "/" + string(r) + "/" + key
+	e, err := c.backend.Get(key)
+	if err != nil {
+		c.error(fmt.Errorf("get %s: %w", key, err))
+	}
+
+	if e != nil && c.Now().Before(e.Expires) {
+		var v T
+		if err := json.Unmarshal(e.Value, &v); err == nil {
+			return v, nil
+		}
+		c.error(fmt.Errorf("decode %s: %w", key, err))
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		c.error(fmt.Errorf("encode %s: %w", key, err))
+		return v, nil
+	}
+
+	if err := c.backend.Set(key, &Entry{Value: b, Expires: c.Now().Add(ttl)}); err != nil {
+		c.error(fmt.Errorf("set %s: %w", key, err))
+	}
+
+	return v, nil
+}
+
+type categoryService struct {
+	c    *Cache
+	next lunchmoney.CategoryService
+}
+
+func (s *categoryService) List(ctx context.Context) ([]*lunchmoney.Category, error) {
+	return cached(ctx, s.c, Categories, "list", func() ([]*lunchmoney.Category, error) { return s.next.List(ctx) })
+}
+
+func (s *categoryService) Get(ctx context.Context, id int64) (*lunchmoney.Category, error) {
+	return cached(ctx, s.c, Categories, strconv.FormatInt(id, 10), func() (*lunchmoney.Category, error) { return s.next.Get(ctx, id) })
+}
+
+type tagService struct {
+	c    *Cache
+	next lunchmoney.TagService
+}
+
+func (s *tagService) List(ctx context.Context) ([]*lunchmoney.Tag, error) {
+	return cached(ctx, s.c, Tags, "list", func() ([]*lunchmoney.Tag, error) { return s.next.List(ctx) })
+}
+
+type userService struct {
+	c    *Cache
+	next lunchmoney.UserService
+}
+
+func (s *userService) Get(ctx context.Context) (*lunchmoney.User, error) {
+	return cached(ctx, s.c, User, "me", func() (*lunchmoney.User, error) { return s.next.Get(ctx) })
+}
+
+type assetService struct {
+	c    *Cache
+	next lunchmoney.AssetService
+}
+
+func (s *assetService) List(ctx context.Context) ([]*lunchmoney.Asset, error) {
+	return cached(ctx, s.c, Assets, "list", func() ([]*lunchmoney.Asset, error) { return s.next.List(ctx) })
+}
+
+func (s *assetService) Update(ctx context.Context, id int64, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error) {
+	defer s.c.invalidate(ctx, Assets)
+	return s.next.Update(ctx, id, asset)
+}
+
+type plaidAccountService struct {
+	c    *Cache
+	next lunchmoney.PlaidAccountService
+}
+
+func (s *plaidAccountService) List(ctx context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	return cached(ctx, s.c, PlaidAccounts, "list", func() ([]*lunchmoney.PlaidAccount, error) { return s.next.List(ctx) })
+}
+
+// transactionService isn't cached, but its writes invalidate tags and
+// assets.
+type transactionService struct {
+	c    *Cache
+	next lunchmoney.TransactionService
+}
+
+func (s *transactionService) List(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+	return s.next.List(ctx, filters)
+}
+
+func (s *transactionService) Get(ctx context.Context, id int64, filters *lunchmoney.TransactionFilters) (*lunchmoney.Transaction, error) {
+	return s.next.Get(ctx, id, filters)
+}
+
+func (s *transactionService) Create(ctx context.Context, itReq lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error) {
+	defer s.c.invalidate(ctx, Tags, Assets)
+	return s.next.Create(ctx, itReq)
+}
+
+func (s *transactionService) Update(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+	defer s.c.invalidate(ctx, Tags, Assets)
+	return s.next.Update(ctx, id, ut)
+}
+
+func (s *transactionService) UpdateWithOptions(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction, opts *lunchmoney.UpdateTransactionOptions) (*lunchmoney.UpdateTransactionResp, error) {
+	defer s.c.invalidate(ctx, Tags, Assets)
+	return s.next.UpdateWithOptions(ctx, id, ut, opts)
+}
+
+func (s *transactionService) BulkUpdate(ctx context.Context, updates map[int64]*lunchmoney.UpdateTransaction, opts *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error) {
+	defer s.c.invalidate(ctx, Tags, Assets)
+	return s.next.BulkUpdate(ctx, updates, opts)
+}
@@ -0,0 +1,235 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/lunchmoneymock"
+	"github.com/icco/lunchmoney/lunchmoneytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gets counts the GET requests srv has received for path.
+func gets(srv *lunchmoneytest.Server, path string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodGet && r.Path == path {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestCache(t *testing.T) {
+	backends := map[string]func(t *testing.T) Backend{
+		"memory": func(*testing.T) Backend { return NewMemory(16) },
+		"disk": func(t *testing.T) Backend {
+			d, err := NewDisk(t.TempDir())
+			require.NoError(t, err)
+			return d
+		},
+	}
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := lunchmoneytest.NewServer(nil)
+			defer srv.Close()
+			client := srv.Client()
+
+			now := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+			c := New(client, &Options{Backend: backend(t), TTLs: map[Resource]time.Duration{Tags: -1}})
+			c.Now = func() time.Time { return now }
+
+			for range 3 {
+				cats, err := client.Categories.List(ctx)
+				require.NoError(t, err)
+				assert.Len(t, cats, 2)
+			}
+			assert.Equal(t, 1, gets(srv, "/v1/categories"))
+
+			_, err := client.Categories.Get(ctx, 83)
+			require.NoError(t, err)
+			_, err = client.Categories.Get(ctx, 83)
+			require.NoError(t, err)
+			assert.Equal(t, 1, gets(srv, "/v1/categories/83"))
+
+			// Past the TTL the entry is fetched again.
+			now = now.Add(2 * time.Hour)
+			_, err = client.Categories.List(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 2, gets(srv, "/v1/categories"))
+
+			// Turned off.
+			_, err = client.Tags.List(ctx)
+			require.NoError(t, err)
+			_, err = client.Tags.List(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 2, gets(srv, "/v1/tags"))
+
+			// Updating an asset invalidates assets.
+			assets, err := client.Assets.List(ctx)
+			require.NoError(t, err)
+			balance := "12.3400"
+			_, err = client.Assets.Update(ctx, assets[0].ID, &lunchmoney.UpdateAsset{Balance: &balance})
+			require.NoError(t, err)
+			assets, err = client.Assets.List(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, balance, assets[0].Balance)
+			assert.Equal(t, 2, gets(srv, "/v1/assets"))
+
+			// So does writing a transaction.
+			_, err = client.Transactions.Create(ctx, lunchmoney.InsertTransactionsRequest{Transactions: []lunchmoney.InsertTransaction{
+				{Date: "2023-03-01", Payee: "Blue Bottle", Amount: "4.50", Status: "uncleared"},
+			}})
+			require.NoError(t, err)
+			_, err = client.Assets.List(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 3, gets(srv, "/v1/assets"))
+
+			// Deprecated methods go through the cache too.
+			_, err = client.GetUser(ctx)
+			require.NoError(t, err)
+			_, err = client.User.Get(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, gets(srv, "/v1/me"))
+
+			c.Invalidate(User)
+			_, err = client.User.Get(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 2, gets(srv, "/v1/me"))
+		})
+	}
+}
+
+func TestCacheErrorsAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	srv := lunchmoneytest.NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+	New(client, nil)
+
+	srv.Inject(lunchmoneytest.Fault{Path: "/v1/plaid_accounts", Times: 1, Status: http.StatusInternalServerError})
+	_, err := client.Plaid.List(ctx)
+	require.Error(t, err)
+
+	accounts, err := client.Plaid.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, accounts, 2)
+}
+
+func TestClientLiteral(t *testing.T) {
+	ctx := context.Background()
+	srv := lunchmoneytest.NewServer(nil)
+	defer srv.Close()
+	base := srv.Client()
+	client := &lunchmoney.Client{HTTP: base.HTTP, Base: base.Base}
+	New(client, nil)
+
+	for range 2 {
+		tags, err := client.Tags.List(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tags, 2)
+	}
+	assert.Equal(t, 1, gets(srv, "/v1/tags"))
+	assert.Equal(t, 1, gets(srv, "/v1/me"), "keys are scoped by the user ID")
+}
+
+func TestSharedBackend(t *testing.T) {
+	ctx := context.Background()
+	srv := lunchmoneytest.NewServer(nil)
+	defer srv.Close()
+	backend, err := NewDisk(t.TempDir())
+	require.NoError(t, err)
+
+	first := srv.Client()
+	New(first, &Options{Backend: backend})
+	cats, err := first.Categories.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, cats, 2)
+
+	// A client with another API key shares the directory but not the entries.
+	second, err := lunchmoney.NewClient("another-token")
+	require.NoError(t, err)
+	second.Categories = &lunchmoneymock.CategoryServiceMock{
+		ListFunc: func(context.Context) ([]*lunchmoney.Category, error) {
+			return []*lunchmoney.Category{{ID: 1, Name: "Theirs"}}, nil
+		},
+	}
+	New(second, &Options{Backend: backend})
+	cats, err = second.Categories.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, cats, 1)
+	assert.Equal(t, "Theirs", cats[0].Name)
+
+	// A client with the first key, such as the same program restarted,
+	// finds the first client's entries.
+	again := srv.Client()
+	New(again, &Options{Backend: backend})
+	_, err = again.Categories.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gets(srv, "/v1/categories"))
+}
+
+func TestMemoryEviction(t *testing.T) {
+	m := NewMemory(2)
+	for _, k := range []string{"a", "b"} {
+		require.NoError(t, m.Set(k, &Entry{Value: []byte(`1`)}))
+	}
+
+	// Touch a so b is the least recently used.
+	e, err := m.Get("a")
+	require.NoError(t, err)
+	require.NotNil(t, e)
+
+	require.NoError(t, m.Set("c", &Entry{Value: []byte(`1`)}))
+	assert.Equal(t, 2, m.Len())
+
+	e, err = m.Get("b")
+	require.NoError(t, err)
+	assert.Nil(t, e)
+}
+
+func TestConcurrentUse(t *testing.T) {
+	ctx := context.Background()
+	srv := lunchmoneytest.NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+	c := New(client, &Options{Backend: NewMemory(4)})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := range 40 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			switch i % 4 {
+			case 0:
+				_, err = client.Categories.List(ctx)
+			case 1:
+				_, err = client.Categories.Get(ctx, int64(83+i%2))
+			case 2:
+				_, err = client.User.Get(ctx)
+			default:
+				c.Invalidate(Categories)
+			}
+			if err != nil {
+				errs <- fmt.Errorf("request %d: %w", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
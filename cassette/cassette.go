@@ -0,0 +1,297 @@
+// Package cassette records Lunch Money API exchanges to disk and replays
+// them, so integration tests can run in CI without a live token.
+//
+// A Recorder wraps a client's transport:
+//
+//	rec, err := cassette.New("testdata/transactions.json", cassette.ModeReplay, client.HTTP.Transport)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Close()
+//	client.HTTP.Transport = rec
+//
+// Run the test once with ModeRecord and a real token to create the cassette.
+// Authorization headers and email addresses are scrubbed before anything is
+// written.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mode is whether a Recorder talks to the API or to a cassette.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never touches the
+	// network. Requests that weren't recorded fail.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests on and saves the exchanges to the cassette
+	// on Close, replacing whatever was there.
+	ModeRecord
+)
+
+// Redacted replaces scrubbed values.
+const Redacted = "REDACTED"
+
+// RedactedEmail replaces scrubbed email addresses.
+const RedactedEmail = "user@example.com"
+
+var emailRE = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Interaction is one recorded exchange.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the file format.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records or replays a cassette. It
+// is safe for concurrent use.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New returns a Recorder for the cassette at path. In ModeReplay the
+// cassette must exist. In ModeRecord requests are sent to next, or to
+// http.DefaultTransport if next is nil.
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := &Recorder{path: path, mode: mode, next: next}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	r.cassette = *c
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// Load reads the cassette at path.
+func Load(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load cassette: %w", err)
+	}
+
+	c := &Cassette{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("decode cassette %s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// Mode returns the mode the Recorder was created with.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+
+	return r.replay(req)
+}
+
+// Close saves the cassette when recording. It does nothing when replaying.
+func (r *Recorder) Close() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(&r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("save cassette: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	in := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  canonicalQuery(req.URL.Query()),
+			Header: scrubHeader(req.Header),
+			Body:   scrub(body),
+		},
+		Response: Response{
+			Status: resp.StatusCode,
+			Header: scrubHeader(resp.Header),
+			Body:   scrub(respBody),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the first unused interaction matching req's method, path
+// and query, so repeated requests are answered in the order they were
+// recorded.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		if err := req.Body.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	query := canonicalQuery(req.URL.Query())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.Path != req.URL.Path || in.Request.Query != query {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no unused interaction for %s %s?%s", r.path, req.Method, req.URL.Path, query)
+}
+
+// readBody reads and replaces *body so it can still be read by the caller.
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+
+	b, err := io.ReadAll(*body)
+	if err != nil {
+		return "", err
+	}
+	if err := (*body).Close(); err != nil {
+		return "", err
+	}
+	*body = io.NopCloser(bytes.NewReader(b))
+
+	return string(b), nil
+}
+
+// canonicalQuery encodes q with keys and each key's values sorted, so
+// parameter order doesn't affect matching.
+func canonicalQuery(q url.Values) string {
+	sorted := url.Values{}
+	for k, vs := range q {
+		vs = append([]string(nil), vs...)
+		sort.Strings(vs)
+		sorted[k] = vs
+	}
+
+	return sorted.Encode()
+}
+
+func scrub(s string) string {
+	return emailRE.ReplaceAllString(s, RedactedEmail)
+}
+
+func scrubHeader(h http.Header) http.Header {
+	ret := http.Header{}
+	for k, vs := range h {
+		switch http.CanonicalHeaderKey(k) {
+		case "Authorization", "Cookie", "Set-Cookie":
+			ret[k] = []string{Redacted}
+		default:
+			for _, v := range vs {
+				ret.Add(k, scrub(v))
+			}
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil
+	}
+
+	return ret
+}
@@ -0,0 +1,127 @@
+package cassette
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/lunchmoneytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "user.json")
+
+	srv := lunchmoneytest.NewServer(nil)
+	client := srv.Client()
+	rec, err := New(path, ModeRecord, client.HTTP.Transport)
+	require.NoError(t, err)
+	client.HTTP.Transport = rec
+
+	user, err := client.User.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1@lunchmoney.dev", user.UserEmail, "callers see the real response while recording")
+
+	start, end := "2020-01-01", "2020-01-31"
+	_, err = client.Transactions.List(ctx, &lunchmoney.TransactionFilters{StartDate: &start, EndDate: &end})
+	require.NoError(t, err)
+	require.NoError(t, rec.Close())
+	srv.Close()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "user-1@lunchmoney.dev")
+	assert.NotContains(t, string(b), lunchmoneytest.Token)
+
+	// Replay with no server and no token at all.
+	client, err = lunchmoney.NewClient("")
+	require.NoError(t, err)
+	rec, err = New(path, ModeReplay, nil)
+	require.NoError(t, err)
+	client.HTTP.Transport = rec
+
+	user, err = client.User.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RedactedEmail, user.UserEmail)
+	assert.Equal(t, "usd", user.PrimaryCurrency)
+
+	txns, err := client.Transactions.List(ctx, &lunchmoney.TransactionFilters{StartDate: &start, EndDate: &end})
+	require.NoError(t, err)
+	assert.Len(t, txns, 2)
+
+	_, err = client.User.Get(ctx)
+	assert.ErrorContains(t, err, "no unused interaction", "each interaction is replayed once")
+}
+
+func TestScrubAuthorization(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+	})
+
+	rec, err := New(path, ModeRecord, next)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://dev.lunchmoney.app/v1/me", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	_, err = rec.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, rec.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "secret")
+	assert.Contains(t, string(b), Redacted)
+}
+
+func TestMatching(t *testing.T) {
+	rec := &Recorder{path: "test", cassette: Cassette{Interactions: []*Interaction{
+		{Request: Request{Method: http.MethodGet, Path: "/v1/transactions", Query: "end_date=2020-01-31&start_date=2020-01-01&tag_id=1&tag_id=2"}, Response: Response{Status: 200, Body: "first"}},
+		{Request: Request{Method: http.MethodGet, Path: "/v1/transactions", Query: "end_date=2020-01-31&start_date=2020-01-01&tag_id=1&tag_id=2"}, Response: Response{Status: 200, Body: "second"}},
+	}}}
+	rec.used = make([]bool, len(rec.cassette.Interactions))
+
+	tests := []struct {
+		name    string
+		method  string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{name: "query order ignored", method: http.MethodGet, url: "/v1/transactions?tag_id=2&start_date=2020-01-01&tag_id=1&end_date=2020-01-31", want: "first"},
+		{name: "repeats replay in order", method: http.MethodGet, url: "/v1/transactions?start_date=2020-01-01&end_date=2020-01-31&tag_id=1&tag_id=2", want: "second"},
+		{name: "exhausted", method: http.MethodGet, url: "/v1/transactions?start_date=2020-01-01&end_date=2020-01-31&tag_id=1&tag_id=2", wantErr: true},
+		{name: "method", method: http.MethodPost, url: "/v1/transactions", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, "https://dev.lunchmoney.app"+tc.url, nil)
+			require.NoError(t, err)
+
+			resp, err := rec.RoundTrip(req)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(b))
+		})
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
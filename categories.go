@@ -1,15 +1,10 @@
 package lunchmoney
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"time"
-
-	"github.com/go-playground/validator/v10"
 )
 
 // CategoriesResponse is the response we get from requesting categories.
@@ -33,49 +28,62 @@ type Category struct {
 	CreatedAt         time.Time `json:"created_at"`          // Creation timestamp
 	IsGroup           bool      `json:"is_group"`            // Whether this category is a group
 	GroupID           int64     `json:"group_id"`            // ID of the parent group, if any
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Category) UnmarshalJSON(data []byte) error {
+	type plain Category
+	extra, err := unmarshalExtra(data, (*plain)(c))
+	c.Extra = extra
+
+	return err
 }
 
-// GetCategories returns a flattened list of all categories in alphabetical
+// MarshalJSON implements json.Marshaler.
+func (c Category) MarshalJSON() ([]byte, error) {
+	type plain Category
+
+	return marshalExtra(plain(c), c.Extra)
+}
+
+// List returns a flattened list of all categories in alphabetical
 // order associated with the user's account. This includes both regular categories
 // and category groups. The returned categories include metadata such as creation time,
 // group relationships, and budget exclusion settings.
 //
 // The context can be used to control the request lifecycle.
 // Returns an error if the API request fails or if the response cannot be validated.
-func (c *Client) GetCategories(ctx context.Context) ([]*Category, error) {
-	validate := validator.New()
+func (s *categoryService) List(ctx context.Context) ([]*Category, error) {
 	options := map[string]string{}
-	body, err := c.Get(ctx, "/v1/categories", options)
+	body, err := s.c.Get(ctx, "/v1/categories", options)
 	if err != nil {
 		return nil, fmt.Errorf("get categories: %w", err)
 	}
 
 	var resp *CategoriesResponse
-	var bodyCopy bytes.Buffer
-	tee := io.TeeReader(body, &bodyCopy)
-	if err := json.NewDecoder(tee).Decode(&resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/categories", body, &resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
 	for _, b := range resp.Categories {
-		if err := validate.StructCtx(ctx, b); err != nil {
-			var validationErrors validator.ValidationErrors
-			var invalidValidationError *validator.InvalidValidationError
-
-			switch {
-			case errors.As(err, &validationErrors):
-				return nil, fmt.Errorf("validating response: %s", validationErrors.Error())
-			case errors.As(err, &invalidValidationError):
-				return nil, fmt.Errorf("validating response (InvalidValidation): %s", invalidValidationError.Error())
-			default:
-				return nil, fmt.Errorf("validating response (%T): %w", err, err)
-			}
+		if err := s.c.checkResponse(ctx, "/v1/categories", b); err != nil {
+			return nil, err
 		}
 	}
 	return resp.Categories, nil
 }
 
-// GetCategory retrieves a single category by its ID.
+// GetCategories returns all categories in alphabetical order.
+//
+// Deprecated: Use Client.Categories.List.
+func (c *Client) GetCategories(ctx context.Context) ([]*Category, error) {
+	return c.CategoryService().List(ctx)
+}
+
+// Get retrieves a single category by its ID.
 // It returns detailed information about the category including its metadata,
 // group relationships, and various settings.
 //
@@ -85,34 +93,29 @@ func (c *Client) GetCategories(ctx context.Context) ([]*Category, error) {
 //
 // Returns the category details or an error if the request fails or
 // the response cannot be validated.
-func (c *Client) GetCategory(ctx context.Context, id int64) (*Category, error) {
+func (s *categoryService) Get(ctx context.Context, id int64) (*Category, error) {
 	options := map[string]string{}
-	body, err := c.Get(ctx, fmt.Sprintf("/v1/categories/%d", id), options)
+	path := fmt.Sprintf("/v1/categories/%d", id)
+	body, err := s.c.Get(ctx, path, options)
 	if err != nil {
 		return nil, fmt.Errorf("error getting category: %w", err)
 	}
 
 	var resp *Category
-	var bodyCopy bytes.Buffer
-	tee := io.TeeReader(body, &bodyCopy)
-	if err := json.NewDecoder(tee).Decode(&resp); err != nil {
+	if err := s.c.decode(ctx, path, body, &resp); err != nil {
 		return nil, fmt.Errorf("error getting category: %w", err)
 	}
 
-	validate := validator.New()
-	if err := validate.StructCtx(ctx, resp); err != nil {
-		var validationErrors validator.ValidationErrors
-		var invalidValidationError *validator.InvalidValidationError
-
-		switch {
-		case errors.As(err, &validationErrors):
-			return nil, fmt.Errorf("validating response: %s", validationErrors.Error())
-		case errors.As(err, &invalidValidationError):
-			return nil, fmt.Errorf("validating response (InvalidValidation): %s", invalidValidationError.Error())
-		default:
-			return nil, fmt.Errorf("validating response (%T): %w", err, err)
-		}
+	if err := s.c.checkResponse(ctx, path, resp); err != nil {
+		return nil, err
 	}
 
 	return resp, nil
 }
+
+// GetCategory retrieves a single category by its ID.
+//
+// Deprecated: Use Client.Categories.Get.
+func (c *Client) GetCategory(ctx context.Context, id int64) (*Category, error) {
+	return c.CategoryService().Get(ctx, id)
+}
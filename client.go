@@ -3,9 +3,12 @@ package lunchmoney
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -38,6 +41,36 @@ func (adt *addAuthHeaderTransport) RoundTrip(req *http.Request) (*http.Response,
 type Client struct {
 	HTTP *http.Client
 	Base *url.URL
+	// Limiter, if set, is waited on before every request.
+	Limiter *RateLimiter
+	// Logger, if set, gets a record for every request with its method,
+	// path, query, status, duration, response size and how many times the
+	// transport retried it. When debug logging is enabled, request and
+	// response bodies are logged too, after passing through Redact.
+	Logger *slog.Logger
+	// Redact scrubs bodies before they are logged. Defaults to RedactBody.
+	Redact func(body string) string
+	// Decoding controls how strictly responses are checked. See DecodeMode.
+	Decoding DecodeMode
+
+	// The API, grouped by resource. NewClient sets each to an implementation
+	// backed by this client. Any of them can be replaced, for example with a
+	// mock from the lunchmoneymock package, and the helpers built on them,
+	// such as NetWorth, will use the replacement. A Client built without
+	// NewClient has none set; methods such as Client.TransactionService
+	// return the default service in place of a nil field, and every package
+	// in this module uses them.
+	Transactions TransactionService
+	Categories   CategoryService
+	Tags         TagService
+	Budgets      BudgetService
+	Assets       AssetService
+	Plaid        PlaidAccountService
+	Crypto       CryptoService
+	Recurring    RecurringExpenseService
+	User         UserService
+
+	tokenID string
 }
 
 // NewClient creates a new client with the specified API key.
@@ -47,12 +80,36 @@ func NewClient(apikey string) (*Client, error) {
 		return nil, fmt.Errorf("invalid base URI: %w", err)
 	}
 
-	return &Client{
+	c := &Client{
 		HTTP: &http.Client{
 			Transport: &addAuthHeaderTransport{T: http.DefaultTransport, Key: apikey},
 		},
-		Base: base,
-	}, nil
+		Base:    base,
+		tokenID: tokenID(apikey),
+	}
+	c.Transactions = &transactionService{c}
+	c.Categories = &categoryService{c}
+	c.Tags = &tagService{c}
+	c.Budgets = &budgetService{c}
+	c.Assets = &assetService{c}
+	c.Plaid = &plaidAccountService{c}
+	c.Crypto = &cryptoService{c}
+	c.Recurring = &recurringExpenseService{c}
+	c.User = &userService{c}
+
+	return c, nil
+}
+
+// TokenID identifies the API key the client was created with by a short hash
+// of it, so data for different keys can be kept apart without holding the
+// key. It is empty for a Client built without NewClient.
+func (c *Client) TokenID() string {
+	return c.tokenID
+}
+
+func tokenID(apikey string) string {
+	h := sha256.Sum256([]byte(apikey))
+	return hex.EncodeToString(h[:8])
 }
 
 // ErrorResponse is json if we get an error from the LM API.
@@ -90,8 +147,12 @@ func (c *Client) Get(ctx context.Context, path string, options map[string]string
 	}
 	u.RawQuery = query.Encode()
 
-	req := &http.Request{Method: http.MethodGet, URL: u}
-	resp, err := c.HTTP.Do(req)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("request (%+v) failed: %w", req, err)
 	}
@@ -113,7 +174,6 @@ func (c *Client) Get(ctx context.Context, path string, options map[string]string
 			return nil, fmt.Errorf("could not decode error response %s: %w", buf.String(), err)
 		}
 
-		// log.Printf("%s -> %+v", buf.String(), errResp)
 		if errResp.Error() != "" {
 			return nil, fmt.Errorf("%s: %s", resp.Status, errResp.Error())
 		}
@@ -160,7 +220,7 @@ func (c *Client) do(ctx context.Context, method string, path string, body any) (
 	}
 
 	req.Header.Add("Content-Type", "application/json")
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("request (%+v) failed: %w", req, err)
 	}
@@ -194,6 +254,34 @@ func (c *Client) do(ctx context.Context, method string, path string, body any) (
 	return &finalReader, nil
 }
 
+// send waits for the rate limiter, if any, and then performs the request,
+// logging it if the client has a Logger and capturing the response if the
+// context asks for it.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.Limiter != nil {
+		if err := c.Limiter.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	if c.Logger != nil {
+		resp, err = c.logged(req)
+	} else {
+		resp, err = c.HTTP.Do(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := captureRaw(req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (*Client) tryToFindError(resp *http.Response, outBuf *bytes.Buffer, failOnDecodeErr bool) error {
 	tee := io.TeeReader(resp.Body, outBuf)
 	errResp := ErrorResponse{}
@@ -0,0 +1,152 @@
+// Command lunchmoney-drift reports differences between Lunch Money API
+// responses and this module's models: fields the API added, fields the
+// models expect but the API no longer sends, and fields whose type changed.
+//
+// Usage:
+//
+//	lunchmoney-drift [-dir testdata | -cassette file] [-baseline file [-update]]
+//
+// With -dir it checks fixture files named after the endpoints, such as
+// testdata/transactions.json; with -cassette, the responses in a recorded
+// cassette. Otherwise it calls the live API with the token in
+// LUNCHMONEY_TOKEN.
+//
+// A baseline file lists accepted drift, one diff per line, so CI fails only
+// on new drift. -update rewrites it with the current diffs. The command
+// exits with status 1 when it reports anything.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/contract"
+)
+
+// errDrift is returned when there is drift to report.
+var errDrift = errors.New("drift found")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			os.Exit(2)
+		case errors.Is(err, errDrift):
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "lunchmoney-drift: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	fset := flag.NewFlagSet("lunchmoney-drift", flag.ContinueOnError)
+	fset.SetOutput(out)
+	dir := fset.String("dir", "", "check the fixture files in `dir`")
+	cassette := fset.String("cassette", "", "check the responses recorded in `file`")
+	baseline := fset.String("baseline", "", "ignore the accepted drift listed in `file`")
+	update := fset.Bool("update", false, "write the current drift to the -baseline file")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+
+	if *dir != "" && *cassette != "" {
+		return fmt.Errorf("-dir and -cassette can't be used together")
+	}
+	if *update && *baseline == "" {
+		return fmt.Errorf("-update requires -baseline")
+	}
+
+	var diffs []contract.Diff
+	var err error
+	switch {
+	case *dir != "":
+		diffs, err = contract.CheckDir(*dir)
+	case *cassette != "":
+		diffs, err = contract.CheckCassette(*cassette)
+	default:
+		diffs, err = checkLive(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *update {
+		var b strings.Builder
+		for _, d := range diffs {
+			fmt.Fprintln(&b, d)
+		}
+
+		return os.WriteFile(*baseline, []byte(b.String()), 0o644)
+	}
+
+	accepted := map[string]bool{}
+	if *baseline != "" {
+		accepted, err = readBaseline(*baseline)
+		if err != nil {
+			return err
+		}
+	}
+
+	found := false
+	for _, d := range diffs {
+		if !accepted[d.String()] {
+			fmt.Fprintln(out, d)
+			found = true
+		}
+	}
+
+	if found {
+		return errDrift
+	}
+
+	return nil
+}
+
+func checkLive(ctx context.Context) ([]contract.Diff, error) {
+	token := os.Getenv("LUNCHMONEY_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("LUNCHMONEY_TOKEN is not set; use -dir or -cassette to check offline")
+	}
+
+	client, err := lunchmoney.NewClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.CheckLive(ctx, client)
+}
+
+// readBaseline reads the accepted diffs. A missing file accepts nothing.
+func readBaseline(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	accepted := map[string]bool{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			accepted[line] = true
+		}
+	}
+
+	return accepted, s.Err()
+}
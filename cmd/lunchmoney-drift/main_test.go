@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	fixtures := t.TempDir()
+	baseline := filepath.Join(t.TempDir(), "baseline.txt")
+	require.NoError(t, os.WriteFile(filepath.Join(fixtures, "tags.json"),
+		[]byte(`[{"id": 1, "name": "Vacation", "description": "", "archived": false}]`), 0o644))
+
+	var out bytes.Buffer
+	err := run(ctx, []string{"-dir", fixtures}, &out)
+	require.ErrorIs(t, err, errDrift)
+	assert.Contains(t, out.String(), "tags: [].archived: added (boolean)\n")
+
+	out.Reset()
+	require.NoError(t, run(ctx, []string{"-dir", fixtures, "-baseline", baseline, "-update"}, &out))
+	assert.Empty(t, out.String())
+
+	require.NoError(t, run(ctx, []string{"-dir", fixtures, "-baseline", baseline}, &out))
+	assert.Empty(t, out.String(), "accepted drift isn't reported")
+
+	b, err := os.ReadFile(baseline)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(baseline, bytes.ReplaceAll(b, []byte("tags: [].archived: added (boolean)\n"), nil), 0o644))
+
+	err = run(ctx, []string{"-dir", fixtures, "-baseline", baseline}, &out)
+	require.ErrorIs(t, err, errDrift)
+	assert.Equal(t, "tags: [].archived: added (boolean)\n", out.String())
+}
+
+func TestRunFlags(t *testing.T) {
+	ctx := context.Background()
+	var out bytes.Buffer
+
+	assert.ErrorContains(t, run(ctx, []string{"-dir", "a", "-cassette", "b"}, &out), "can't be used together")
+	assert.ErrorContains(t, run(ctx, []string{"-dir", "a", "-update"}, &out), "requires -baseline")
+
+	t.Setenv("LUNCHMONEY_TOKEN", "")
+	assert.ErrorContains(t, run(ctx, nil, &out), "LUNCHMONEY_TOKEN")
+}
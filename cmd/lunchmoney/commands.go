@@ -0,0 +1,509 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+var commands = []command{
+	{"transactions", "list", "list transactions, filtered by flags", listTransactions},
+	{"transactions", "get", "show a single transaction", getTransaction},
+	{"transactions", "insert", "create a transaction", insertTransaction},
+	{"transactions", "update", "change fields on a transaction", updateTransaction},
+	{"categories", "list", "list categories and category groups", listCategories},
+	{"categories", "get", "show a single category", getCategory},
+	{"tags", "list", "list tags", listTags},
+	{"budgets", "list", "show budgets and spending per month", listBudgets},
+	{"assets", "list", "list manually managed assets", listAssets},
+	{"assets", "update", "change an asset's balance or details", updateAsset},
+	{"plaid", "list", "list Plaid connected accounts", listPlaidAccounts},
+	{"recurring", "list", "list recurring expenses for a month", listRecurring},
+	{"user", "get", "show the current user", getUser},
+}
+
+// int64Ptr returns nil for zero, so unset ID flags aren't sent.
+func int64Ptr(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+
+	return &v
+}
+
+func transactionTable(txns []*lunchmoney.Transaction) *table {
+	t := &table{header: []string{"id", "date", "payee", "amount", "currency", "category", "account", "status", "notes"}}
+	for _, tx := range txns {
+		account := tx.AccountDisplayName
+		if account == "" {
+			account = tx.PlaidAccountDisplayName
+		}
+		if account == "" {
+			account = tx.AssetDisplayName
+		}
+		t.add(tx.ID, tx.Date, tx.Payee, tx.Amount, tx.Currency, tx.CategoryName, account, tx.Status, tx.Notes)
+	}
+
+	return t
+}
+
+func listTransactions(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("transactions list")
+	start := fs.String("start", "", "start `date` (YYYY-MM-DD), requires -end")
+	end := fs.String("end", "", "end `date` (YYYY-MM-DD), requires -start")
+	category := fs.Int64("category", 0, "only transactions in category `id`")
+	asset := fs.Int64("asset", 0, "only transactions in asset `id`")
+	plaid := fs.Int64("plaid", 0, "only transactions in Plaid account `id`")
+	tag := fs.Int64("tag", 0, "only transactions with tag `id`")
+	recurring := fs.Int64("recurring", 0, "only transactions matching recurring expense `id`")
+	limit := fs.Int64("limit", 0, "return at most `n` transactions")
+	offset := fs.Int64("offset", 0, "skip the first `n` transactions")
+	debitAsNegative := fs.Bool("debit-as-negative", false, "show debits as negative amounts")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if (*start == "") != (*end == "") {
+		return fmt.Errorf("-start and -end must be used together")
+	}
+
+	filters := &lunchmoney.TransactionFilters{
+		CategoryID:     int64Ptr(*category),
+		AssetID:        int64Ptr(*asset),
+		PlaidAccountID: int64Ptr(*plaid),
+		TagID:          int64Ptr(*tag),
+		RecurringID:    int64Ptr(*recurring),
+		Limit:          int64Ptr(*limit),
+		Offset:         int64Ptr(*offset),
+	}
+	if *start != "" {
+		filters.StartDate, filters.EndDate = start, end
+	}
+	if *debitAsNegative {
+		filters.DebitAsNegative = debitAsNegative
+	}
+
+	txns, err := a.client.TransactionService().List(ctx, filters)
+	if err != nil {
+		return err
+	}
+
+	return a.print(*format, txns, transactionTable(txns))
+}
+
+func getTransaction(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("transactions get")
+	id, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	t, err := a.client.TransactionService().Get(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+
+	return a.print(*format, t, transactionTable([]*lunchmoney.Transaction{t}))
+}
+
+// tagRefs parses a comma separated list of tag IDs or names.
+func tagRefs(v string) []lunchmoney.TagRef {
+	ret := []lunchmoney.TagRef{}
+	for _, s := range strings.Split(v, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+			ret = append(ret, lunchmoney.TagByID(id))
+		} else {
+			ret = append(ret, lunchmoney.TagByName(s))
+		}
+	}
+
+	return ret
+}
+
+func insertTransaction(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("transactions insert")
+	date := fs.String("date", time.Now().Format("2006-01-02"), "transaction `date` (YYYY-MM-DD)")
+	payee := fs.String("payee", "", "payee")
+	amount := fs.String("amount", "", "`amount`, positive for debits unless -debit-as-negative (required)")
+	currency := fs.String("currency", "", "three letter currency `code`, defaults to your primary currency")
+	notes := fs.String("notes", "", "notes")
+	category := fs.Int64("category", 0, "category `id`")
+	asset := fs.Int64("asset", 0, "asset `id`")
+	plaid := fs.Int64("plaid", 0, "Plaid account `id`")
+	status := fs.String("status", "uncleared", "`status`: cleared or uncleared")
+	externalID := fs.String("external-id", "", "external `id`; Lunch Money never inserts two transactions with the same one in an asset")
+	skipDuplicates := fs.Bool("skip-duplicates", false, "skip the transaction if one with the same date, payee and amount exists")
+	debitAsNegative := fs.Bool("debit-as-negative", false, "treat negative amounts as debits")
+	yes := fs.Bool("y", false, "don't ask for confirmation")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if *amount == "" {
+		return fmt.Errorf("-amount is required")
+	}
+
+	t := lunchmoney.InsertTransaction{
+		Date:           *date,
+		Amount:         *amount,
+		Payee:          *payee,
+		Currency:       strings.ToLower(*currency),
+		Notes:          *notes,
+		CategoryID:     int64Ptr(*category),
+		AssetID:        int64Ptr(*asset),
+		PlaidAccountID: int64Ptr(*plaid),
+		Status:         *status,
+		ExternalID:     *externalID,
+	}
+
+	ok, err := a.confirm(*yes, "Insert transaction %s?", describe(map[string]string{
+		"date": t.Date, "payee": t.Payee, "amount": t.Amount, "currency": t.Currency,
+	}))
+	if err != nil || !ok {
+		return err
+	}
+
+	resp, err := a.client.TransactionService().Create(ctx, lunchmoney.InsertTransactionsRequest{
+		DebitAsNegative: *debitAsNegative,
+		SkipDuplicates:  *skipDuplicates,
+		Transactions:    []lunchmoney.InsertTransaction{t},
+	})
+	if err != nil {
+		return err
+	}
+
+	tbl := &table{header: []string{"id"}}
+	for _, id := range resp.IDs {
+		tbl.add(id)
+	}
+
+	return a.print(*format, resp, tbl)
+}
+
+func updateTransaction(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("transactions update")
+	date := fs.String("date", "", "new `date` (YYYY-MM-DD)")
+	payee := fs.String("payee", "", "new payee")
+	amount := fs.String("amount", "", "new `amount`")
+	currency := fs.String("currency", "", "new currency `code`")
+	notes := fs.String("notes", "", "new notes")
+	status := fs.String("status", "", "new `status`: cleared or uncleared")
+	category := fs.Int64("category", 0, "new category `id`, 0 to clear")
+	asset := fs.Int64("asset", 0, "new asset `id`, 0 to clear")
+	plaid := fs.Int64("plaid", 0, "new Plaid account `id`, 0 to clear")
+	recurring := fs.Int64("recurring", 0, "new recurring expense `id`, 0 to clear")
+	tags := fs.String("tags", "", "replace tags with a comma separated `list` of tag IDs or names")
+	debitAsNegative := fs.Bool("debit-as-negative", false, "treat a negative -amount as a debit")
+	skipBalance := fs.Bool("skip-balance-update", false, "don't update the asset's balance")
+	yes := fs.Bool("y", false, "don't ask for confirmation")
+	id, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	set := setFlags(fs)
+	ut := &lunchmoney.UpdateTransaction{}
+	changes := map[string]string{}
+	str := func(name string, v *string, dst **string) {
+		if set[name] {
+			*dst = v
+			changes[name] = *v
+		}
+	}
+	str("date", date, &ut.Date)
+	str("payee", payee, &ut.Payee)
+	str("amount", amount, &ut.Amount)
+	str("notes", notes, &ut.Notes)
+	str("status", status, &ut.Status)
+	if set["currency"] {
+		lower := strings.ToLower(*currency)
+		ut.Currency = &lower
+		changes["currency"] = lower
+	}
+
+	nullable := func(name string, v int64, dst *lunchmoney.Nullable[int64]) {
+		if !set[name] {
+			return
+		}
+
+		if v == 0 {
+			*dst = lunchmoney.Clear[int64]()
+			changes[name] = "none"
+		} else {
+			*dst = lunchmoney.Set(v)
+			changes[name] = strconv.FormatInt(v, 10)
+		}
+	}
+	nullable("category", *category, &ut.CategoryID)
+	nullable("asset", *asset, &ut.AssetID)
+	nullable("plaid", *plaid, &ut.PlaidAccountID)
+	nullable("recurring", *recurring, &ut.RecurringID)
+
+	if set["tags"] {
+		ut.Tags = tagRefs(*tags)
+		changes["tags"] = *tags
+	}
+
+	if len(changes) == 0 {
+		return fmt.Errorf("nothing to update")
+	}
+
+	ok, err := a.confirm(*yes, "Update transaction %d: %s?", id, describe(changes))
+	if err != nil || !ok {
+		return err
+	}
+
+	resp, err := a.client.TransactionService().UpdateWithOptions(ctx, id, ut, &lunchmoney.UpdateTransactionOptions{
+		DebitAsNegative:   *debitAsNegative,
+		SkipBalanceUpdate: *skipBalance,
+	})
+	if err != nil {
+		return err
+	}
+
+	tbl := &table{header: []string{"id", "updated"}}
+	tbl.add(id, resp.Updated)
+
+	return a.print(*format, resp, tbl)
+}
+
+func categoryTable(cats []*lunchmoney.Category) *table {
+	t := &table{header: []string{"id", "name", "group_id", "is_group", "is_income", "exclude_from_budget", "exclude_from_totals", "description"}}
+	for _, c := range cats {
+		t.add(c.ID, c.Name, c.GroupID, c.IsGroup, c.IsIncome, c.ExcludeFromBudget, c.ExcludeFromTotals, c.Description)
+	}
+
+	return t
+}
+
+func listCategories(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("categories list")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	cats, err := a.client.CategoryService().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(*format, cats, categoryTable(cats))
+}
+
+func getCategory(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("categories get")
+	id, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client.CategoryService().Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.print(*format, c, categoryTable([]*lunchmoney.Category{c}))
+}
+
+func listTags(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("tags list")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	tags, err := a.client.TagService().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "name", "description"}}
+	for _, tag := range tags {
+		t.add(tag.ID, tag.Name, tag.Description)
+	}
+
+	return a.print(*format, tags, t)
+}
+
+func listBudgets(ctx context.Context, a *app, args []string) error {
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	fs, format := a.flags("budgets list")
+	start := fs.String("start", first.Format("2006-01-02"), "start `date` (YYYY-MM-DD)")
+	end := fs.String("end", first.AddDate(0, 1, -1).Format("2006-01-02"), "end `date` (YYYY-MM-DD)")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	budgets, err := a.client.BudgetService().List(ctx, &lunchmoney.BudgetFilters{StartDate: *start, EndDate: *end})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"category_id", "category", "group", "month", "budget", "currency", "spending", "transactions"}}
+	for _, b := range budgets {
+		months := make([]string, 0, len(b.Data))
+		for m := range b.Data {
+			months = append(months, m)
+		}
+		sort.Strings(months)
+
+		for _, m := range months {
+			d := b.Data[m]
+			t.add(b.CategoryID, b.CategoryName, b.CategoryGroupName, m, d.BudgetAmount.String(), d.BudgetCurrency, d.SpendingToBase, d.NumTransactions)
+		}
+	}
+
+	return a.print(*format, budgets, t)
+}
+
+func listAssets(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("assets list")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	assets, err := a.client.AssetService().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "name", "type", "institution", "balance", "currency", "balance_as_of", "status"}}
+	for _, as := range assets {
+		name := as.DisplayName
+		if name == "" {
+			name = as.Name
+		}
+		t.add(as.ID, name, as.TypeName, as.InstitutionName, as.Balance, as.Currency, as.BalanceAsOf.Format("2006-01-02"), as.Status)
+	}
+
+	return a.print(*format, assets, t)
+}
+
+func updateAsset(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("assets update")
+	balance := fs.String("balance", "", "new `balance`")
+	asOf := fs.String("as-of", "", "`time` of the balance (RFC 3339), defaults to now")
+	currency := fs.String("currency", "", "new currency `code`")
+	name := fs.String("name", "", "new name")
+	displayName := fs.String("display-name", "", "new display name")
+	institution := fs.String("institution", "", "new institution name")
+	yes := fs.Bool("y", false, "don't ask for confirmation")
+	id, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	set := setFlags(fs)
+	update := &lunchmoney.UpdateAsset{}
+	changes := map[string]string{}
+	str := func(name string, v *string, dst **string) {
+		if set[name] {
+			*dst = v
+			changes[name] = *v
+		}
+	}
+	str("balance", balance, &update.Balance)
+	str("as-of", asOf, &update.BalanceAsOf)
+	str("name", name, &update.Name)
+	str("display-name", displayName, &update.DisplayName)
+	str("institution", institution, &update.InstitutionName)
+	if set["currency"] {
+		lower := strings.ToLower(*currency)
+		update.Currency = &lower
+		changes["currency"] = lower
+	}
+
+	if len(changes) == 0 {
+		return fmt.Errorf("nothing to update")
+	}
+
+	ok, err := a.confirm(*yes, "Update asset %d: %s?", id, describe(changes))
+	if err != nil || !ok {
+		return err
+	}
+
+	as, err := a.client.AssetService().Update(ctx, id, update)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "name", "balance", "currency"}}
+	t.add(as.ID, as.Name, as.Balance, as.Currency)
+
+	return a.print(*format, as, t)
+}
+
+func listPlaidAccounts(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("plaid list")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	accounts, err := a.client.PlaidAccountService().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "name", "type", "mask", "institution", "balance", "currency", "status"}}
+	for _, p := range accounts {
+		name := p.DisplayName
+		if name == "" {
+			name = p.Name
+		}
+		t.add(p.ID, name, p.Type, p.Mask, p.InstitutionName, p.Balance, p.Currency, p.Status)
+	}
+
+	return a.print(*format, accounts, t)
+}
+
+func listRecurring(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("recurring list")
+	start := fs.String("start", "", "first day of the `month` (YYYY-MM-DD), defaults to this month")
+	debitAsNegative := fs.Bool("debit-as-negative", false, "show debits as negative amounts")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	expenses, err := a.client.RecurringExpenseService().List(ctx, &lunchmoney.RecurringExpenseFilters{
+		StartDate:       *start,
+		DebitAsNegative: *debitAsNegative,
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"id", "payee", "amount", "currency", "cadence", "billing_date", "type", "start_date", "end_date"}}
+	for _, r := range expenses {
+		t.add(r.ID, r.Payee, r.Amount, r.Currency, r.Cadence, r.BillingDate, r.Type, r.StartDate, r.EndDate)
+	}
+
+	return a.print(*format, expenses, t)
+}
+
+func getUser(ctx context.Context, a *app, args []string) error {
+	fs, format := a.flags("user get")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	u, err := a.client.UserService().Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"user_id", "name", "email", "account_id", "budget_name", "primary_currency"}}
+	t.add(u.UserID, u.UserName, u.UserEmail, u.AccountID, u.BudgetName, u.PrimaryCurrency)
+
+	return a.print(*format, u, t)
+}
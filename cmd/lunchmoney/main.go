@@ -0,0 +1,210 @@
+// Command lunchmoney is a command line client for the Lunch Money API.
+//
+// Usage:
+//
+//	lunchmoney <resource> [verb] [flags] [id]
+//
+// Resources are transactions, categories, tags, budgets, assets, plaid,
+// recurring and user. Every command takes -o table, json or csv. Commands
+// that change data ask for confirmation on stderr unless -y is given. The
+// API token is read from LUNCHMONEY_TOKEN.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/icco/lunchmoney"
+)
+
+type app struct {
+	client *lunchmoney.Client
+	in     *bufio.Reader
+	out    io.Writer
+	// errOut gets prompts, so they don't end up in -o json or csv output.
+	errOut io.Writer
+}
+
+// command is a single verb on a resource. The first verb registered for a
+// resource is its default.
+type command struct {
+	resource string
+	verb     string
+	summary  string
+	run      func(ctx context.Context, a *app, args []string) error
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	token := os.Getenv("LUNCHMONEY_TOKEN")
+	if token == "" && !wantsHelp(os.Args[1:]) {
+		fmt.Fprintln(os.Stderr, "lunchmoney: LUNCHMONEY_TOKEN is not set")
+		os.Exit(1)
+	}
+
+	client, err := lunchmoney.NewClient(token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lunchmoney: %v\n", err)
+		os.Exit(1)
+	}
+
+	a := &app{client: client, in: bufio.NewReader(os.Stdin), out: os.Stdout, errOut: os.Stderr}
+	if err := a.run(ctx, os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			if len(os.Args) > 1 {
+				os.Exit(0)
+			}
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "lunchmoney: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func wantsHelp(args []string) bool {
+	return len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help"
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	if wantsHelp(args) {
+		a.usage()
+		return flag.ErrHelp
+	}
+
+	resource, args := args[0], args[1:]
+	var verbs []command
+	for _, c := range commands {
+		if c.resource == resource {
+			verbs = append(verbs, c)
+		}
+	}
+	if len(verbs) == 0 {
+		a.usage()
+		return fmt.Errorf("unknown resource %q", resource)
+	}
+
+	cmd := verbs[0]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		for _, c := range verbs {
+			if c.verb == args[0] {
+				cmd, args = c, args[1:]
+				break
+			}
+		}
+	}
+
+	return cmd.run(ctx, a, args)
+}
+
+func (a *app) usage() {
+	fmt.Fprintln(a.out, "usage: lunchmoney <resource> [verb] [flags] [id]")
+	fmt.Fprintln(a.out)
+
+	tw := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", c.resource, c.verb, c.summary)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(a.out)
+	fmt.Fprintln(a.out, "Run a command with -h for its flags. The token is read from LUNCHMONEY_TOKEN.")
+}
+
+// flags returns a flag set for a command with the output format flag added.
+func (a *app) flags(c string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("lunchmoney "+c, flag.ContinueOnError)
+	fs.SetOutput(a.out)
+	format := fs.String("o", "table", "output `format`: table, json or csv")
+
+	return fs, format
+}
+
+// parse parses flags, allowing positional arguments before or after them.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		positional, args = append(positional, args[0]), args[1:]
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return append(positional, fs.Args()...), nil
+}
+
+// parseID parses flags and a single required ID argument.
+func parseID(fs *flag.FlagSet, args []string) (int64, error) {
+	rest, err := parse(fs, args)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rest) != 1 {
+		return 0, fmt.Errorf("expected one id, got %d arguments", len(rest))
+	}
+
+	id, err := strconv.ParseInt(rest[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid id: %w", rest[0], err)
+	}
+
+	return id, nil
+}
+
+// setFlags returns the names of flags given on the command line.
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	ret := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { ret[f.Name] = true })
+
+	return ret
+}
+
+// confirm asks the user to confirm a change, unless yes is set.
+func (a *app) confirm(yes bool, format string, args ...any) (bool, error) {
+	if yes {
+		return true, nil
+	}
+
+	fmt.Fprintf(a.errOut, format+" [y/N] ", args...)
+	line, err := a.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		fmt.Fprintln(a.errOut, "aborted")
+		return false, nil
+	}
+}
+
+// describe lists the fields of a change for a confirmation prompt.
+func describe(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%q", k, fields[k])
+	}
+
+	return strings.Join(parts, " ")
+}
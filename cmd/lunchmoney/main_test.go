@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transactionsJSON = `{"transactions": [
+	{"id": 1, "date": "2023-01-05", "payee": "Blue Bottle", "amount": "4.5000", "currency": "usd", "status": "cleared", "category_name": "Coffee", "notes": "latte\twith oat milk"},
+	{"id": 2, "date": "2023-01-06", "payee": "Payroll", "amount": "-1000.0000", "currency": "usd", "status": "cleared"}
+]}`
+
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   string
+}
+
+// testApp returns an app talking to a server that answers every request
+// with response, and a record of the requests it received.
+func testApp(t *testing.T, response, stdin string) (*app, *bytes.Buffer, *[]request) {
+	t.Helper()
+
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, request{method: r.Method, path: r.URL.Path, query: r.URL.Query(), body: string(body)})
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := lunchmoney.NewClient("test-token")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	return &app{client: client, in: bufio.NewReader(strings.NewReader(stdin)), out: out, errOut: &bytes.Buffer{}}, out, &requests
+}
+
+func TestListTransactions(t *testing.T) {
+	a, out, requests := testApp(t, transactionsJSON, "")
+	err := a.run(context.Background(), []string{"transactions", "-start", "2023-01-01", "-end", "2023-01-31", "-category", "7", "-o", "csv"})
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 1)
+	r := (*requests)[0]
+	assert.Equal(t, "/v1/transactions", r.path)
+	assert.Equal(t, "2023-01-01", r.query.Get("start_date"))
+	assert.Equal(t, "2023-01-31", r.query.Get("end_date"))
+	assert.Equal(t, "7", r.query.Get("category_id"))
+	assert.False(t, r.query.Has("asset_id"))
+
+	rows, err := csv.NewReader(out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"id", "date", "payee", "amount", "currency", "category", "account", "status", "notes"}, rows[0])
+	assert.Equal(t, []string{"1", "2023-01-05", "Blue Bottle", "4.5000", "usd", "Coffee", "", "cleared", "latte\twith oat milk"}, rows[1])
+}
+
+func TestOutputFormats(t *testing.T) {
+	a, out, _ := testApp(t, transactionsJSON, "")
+	require.NoError(t, a.run(context.Background(), []string{"transactions", "list"}))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "ID  DATE"))
+	assert.Contains(t, lines[1], "latte with oat milk")
+
+	a, out, _ = testApp(t, transactionsJSON, "")
+	require.NoError(t, a.run(context.Background(), []string{"transactions", "-o", "json"}))
+	var txns []*lunchmoney.Transaction
+	require.NoError(t, json.Unmarshal(out.Bytes(), &txns))
+	assert.Len(t, txns, 2)
+
+	a, _, _ = testApp(t, transactionsJSON, "")
+	assert.ErrorContains(t, a.run(context.Background(), []string{"transactions", "-o", "xml"}), "unknown output format")
+}
+
+func TestUpdateTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		wantBody string
+		wantOut  string
+	}{
+		{
+			name:     "confirmed",
+			args:     []string{"transactions", "update", "12", "-payee", "Blue Bottle", "-category", "0"},
+			stdin:    "y\n",
+			wantBody: `{"transaction": {"payee": "Blue Bottle", "category_id": null}}`,
+			wantOut:  `Update transaction 12: category="none" payee="Blue Bottle"? [y/N]`,
+		},
+		{
+			name:    "declined",
+			args:    []string{"transactions", "update", "12", "-payee", "Blue Bottle"},
+			stdin:   "n\n",
+			wantOut: "aborted",
+		},
+		{
+			name:     "yes flag",
+			args:     []string{"transactions", "update", "-y", "-tags", "work,3", "12"},
+			wantBody: `{"transaction": {"tags": ["work", 3]}}`,
+		},
+		{
+			name:     "recurring",
+			args:     []string{"transactions", "update", "-y", "-recurring", "5", "12"},
+			wantBody: `{"transaction": {"recurring_id": 5}}`,
+		},
+		{
+			name:     "clear recurring",
+			args:     []string{"transactions", "update", "-y", "-recurring", "0", "12"},
+			wantBody: `{"transaction": {"recurring_id": null}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, _, requests := testApp(t, `{"updated": true}`, tc.stdin)
+			require.NoError(t, a.run(context.Background(), tc.args))
+			assert.Contains(t, a.errOut.(*bytes.Buffer).String(), tc.wantOut)
+
+			if tc.wantBody == "" {
+				assert.Empty(t, *requests)
+				return
+			}
+
+			require.Len(t, *requests, 1)
+			r := (*requests)[0]
+			assert.Equal(t, http.MethodPut, r.method)
+			assert.Equal(t, "/v1/transactions/12", r.path)
+			assert.JSONEq(t, tc.wantBody, r.body)
+		})
+	}
+}
+
+func TestInsertTransaction(t *testing.T) {
+	a, out, requests := testApp(t, `{"ids": [99]}`, "yes\n")
+	err := a.run(context.Background(), []string{"transactions", "insert", "-date", "2023-01-05", "-amount", "4.50", "-payee", "Blue Bottle", "-currency", "USD"})
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, http.MethodPost, (*requests)[0].method)
+	assert.JSONEq(t, `{"transactions": [{"date": "2023-01-05", "amount": "4.50", "payee": "Blue Bottle", "currency": "usd", "status": "uncleared"}]}`, (*requests)[0].body)
+	assert.Contains(t, out.String(), "99")
+	assert.NotContains(t, out.String(), "[y/N]", "prompts go to stderr")
+}
+
+func TestInsertTransactionSkipDuplicates(t *testing.T) {
+	a, _, requests := testApp(t, `{"ids": [99]}`, "")
+	err := a.run(context.Background(), []string{"transactions", "insert", "-y", "-amount", "4.50", "-external-id", "abc"})
+	require.NoError(t, err)
+	require.Len(t, *requests, 1)
+	assert.NotContains(t, (*requests)[0].body, "skip_duplicates", "an external ID alone doesn't turn on date, payee and amount dedupe")
+
+	a, _, requests = testApp(t, `{"ids": [99]}`, "")
+	err = a.run(context.Background(), []string{"transactions", "insert", "-y", "-amount", "4.50", "-skip-duplicates"})
+	require.NoError(t, err)
+	require.Len(t, *requests, 1)
+	assert.Contains(t, (*requests)[0].body, `"skip_duplicates":true`)
+}
+
+func TestInsertTransactionJSON(t *testing.T) {
+	a, out, _ := testApp(t, `{"ids": [99]}`, "y\n")
+	err := a.run(context.Background(), []string{"transactions", "insert", "-date", "2023-01-05", "-amount", "4.50", "-payee", "Blue Bottle", "-o", "json"})
+	require.NoError(t, err)
+
+	var got any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got), "stdout is only JSON: %s", out.String())
+	assert.Contains(t, a.errOut.(*bytes.Buffer).String(), "Insert transaction")
+}
+
+func TestListRecurring(t *testing.T) {
+	a, out, requests := testApp(t, `{"recurring_expenses": [
+		{"id": 5, "payee": "Netflix", "amount": "15.4900", "currency": "usd", "cadence": "monthly", "billing_date": "2023-01-05", "type": "cleared"}
+	]}`, "")
+	require.NoError(t, a.run(context.Background(), []string{"recurring", "-start", "2023-01-01", "-debit-as-negative", "-o", "csv"}))
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "/v1/recurring_expenses", (*requests)[0].path)
+	assert.Equal(t, url.Values{"start_date": {"2023-01-01"}, "debit_as_negative": {"true"}}, (*requests)[0].query)
+
+	rows, err := csv.NewReader(out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"5", "Netflix", "15.4900", "usd", "monthly", "2023-01-05", "cleared", "", ""}, rows[1])
+
+	a, _, requests = testApp(t, `{"recurring_expenses": []}`, "")
+	require.NoError(t, a.run(context.Background(), []string{"recurring"}))
+	assert.Empty(t, (*requests)[0].query, "unset filters aren't sent")
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "unknown resource", args: []string{"widgets"}, want: "unknown resource"},
+		{name: "missing id", args: []string{"transactions", "get"}, want: "expected one id"},
+		{name: "bad id", args: []string{"categories", "get", "abc"}, want: "not a valid id"},
+		{name: "nothing to update", args: []string{"assets", "update", "1", "-y"}, want: "nothing to update"},
+		{name: "half a date range", args: []string{"transactions", "-start", "2023-01-01"}, want: "must be used together"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, _, requests := testApp(t, `{}`, "")
+			assert.ErrorContains(t, a.run(context.Background(), tc.args), tc.want)
+			assert.Empty(t, *requests)
+		})
+	}
+}
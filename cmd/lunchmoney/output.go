@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// table is a command's result in tabular form.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(cells ...any) {
+	row := make([]string, len(cells))
+	for i, c := range cells {
+		switch v := c.(type) {
+		case string:
+			row[i] = v
+		case int64:
+			row[i] = optional(v)
+		case int:
+			row[i] = optional(int64(v))
+		case float64:
+			row[i] = strconv.FormatFloat(v, 'f', 2, 64)
+		case bool:
+			row[i] = strconv.FormatBool(v)
+		default:
+			row[i] = fmt.Sprint(v)
+		}
+	}
+	t.rows = append(t.rows, row)
+}
+
+// optional formats an ID, leaving zero blank.
+func optional(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
+
+// print writes v as JSON, or t as a table or CSV.
+func (a *app) print(format string, v any, t *table) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "csv":
+		w := csv.NewWriter(a.out)
+		if err := w.Write(t.header); err != nil {
+			return err
+		}
+		if err := w.WriteAll(t.rows); err != nil {
+			return err
+		}
+		return w.Error()
+	case "table":
+		tw := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(t.header, "\t")))
+		for _, row := range t.rows {
+			cells := make([]string, len(row))
+			for i, c := range row {
+				cells[i] = strings.Join(strings.Fields(c), " ")
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,341 @@
+// Package contract detects drift between the Lunch Money API and this
+// module's models. It compares the keys and JSON types of API responses
+// against the Go structs they decode into, by reflection, and reports
+// fields the API added, fields the models expect that the API no longer
+// sends, and fields whose type changed.
+//
+// Responses can come from the live API, from recorded cassettes, or from
+// fixture files, so the check can run in CI without a token:
+//
+//	diffs, err := contract.CheckDir("lunchmoneytest/testdata")
+//
+// The lunchmoney-drift command wraps these functions.
+package contract
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kind is a JSON type.
+type Kind string
+
+// The JSON types. A null fits any field, so Null is only reported for
+// added fields.
+const (
+	String Kind = "string"
+	Number Kind = "number"
+	Bool   Kind = "boolean"
+	Object Kind = "object"
+	Array  Kind = "array"
+	Null   Kind = "null"
+)
+
+// Change is the kind of drift a Diff reports.
+type Change string
+
+const (
+	// Added is a field in the response that the model doesn't have.
+	Added Change = "added"
+	// Missing is a field the model requires that the response doesn't have.
+	// Fields tagged omitempty are optional and never reported missing, nor
+	// are the error fields of response envelopes, which are only set when a
+	// request fails.
+	Missing Change = "missing"
+	// TypeChanged is a field whose JSON type doesn't fit the model's.
+	TypeChanged Change = "type changed"
+)
+
+// Diff is a single difference between a response and its model.
+type Diff struct {
+	// Endpoint is the name of the Endpoint the response came from.
+	Endpoint string
+	// Path locates the field, such as "transactions[].tags[].name". "[]"
+	// stands for every element of an array and "*" for every value of a map.
+	Path   string
+	Change Change
+	// Want is the type the model expects. It is empty for Added.
+	Want Kind
+	// Got is the type in the response. It is empty for Missing.
+	Got Kind
+}
+
+func (d Diff) String() string {
+	var detail string
+	switch d.Change {
+	case Added:
+		detail = fmt.Sprintf("added (%s)", d.Got)
+	case Missing:
+		detail = fmt.Sprintf("missing (want %s)", d.Want)
+	default:
+		detail = fmt.Sprintf("%s: want %s, got %s", d.Change, d.Want, d.Got)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", d.Endpoint, d.Path, detail)
+}
+
+var (
+	unmarshalerType     = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	timeType            = reflect.TypeFor[time.Time]()
+	numberType          = reflect.TypeFor[json.Number]()
+	extraType           = reflect.TypeFor[map[string]json.RawMessage]()
+)
+
+// errorFields are the names the API reports failures under.
+var errorFields = map[string]bool{"error": true, "errors": true}
+
+// Compare reports how body differs from the JSON form of model, which is
+// usually a zero value such as lunchmoney.User{}. The Endpoint of the
+// returned diffs is empty.
+func Compare(body []byte, model any) ([]Diff, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	c := &comparer{seen: map[Diff]bool{}}
+	c.compare("", reflect.TypeOf(model), v)
+	sort.Slice(c.diffs, func(i, j int) bool {
+		if c.diffs[i].Path != c.diffs[j].Path {
+			return c.diffs[i].Path < c.diffs[j].Path
+		}
+		return c.diffs[i].Change < c.diffs[j].Change
+	})
+
+	return c.diffs, nil
+}
+
+type comparer struct {
+	diffs []Diff
+	// seen drops the repeats that every element of an array would add.
+	seen map[Diff]bool
+}
+
+func (c *comparer) add(d Diff) {
+	if !c.seen[d] {
+		c.seen[d] = true
+		c.diffs = append(c.diffs, d)
+	}
+}
+
+func (c *comparer) compare(path string, t reflect.Type, v any) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	got, ok := kindOf(v)
+	want, known := expected(t)
+	if !ok || !known {
+		return
+	}
+
+	if got != want {
+		if t == numberType && got == String {
+			// json.Number also decodes from a quoted number.
+			return
+		}
+		c.add(Diff{Path: path, Change: TypeChanged, Want: want, Got: got})
+		return
+	}
+
+	switch {
+	case want == Object && t.Kind() == reflect.Struct:
+		c.compareStruct(path, t, v.(map[string]any))
+	case want == Object:
+		for _, e := range v.(map[string]any) {
+			c.compare(join(path, "*"), t.Elem(), e)
+		}
+	case want == Array:
+		for _, e := range v.([]any) {
+			c.compare(path+"[]", t.Elem(), e)
+		}
+	}
+}
+
+func (c *comparer) compareStruct(path string, t reflect.Type, obj map[string]any) {
+	fields := fieldsOf(t)
+
+	for key, e := range obj {
+		f, ok := fields[key]
+		if !ok {
+			got, ok := kindOf(e)
+			if !ok {
+				got = Null
+			}
+			c.add(Diff{Path: join(path, key), Change: Added, Got: got})
+			continue
+		}
+
+		if f.asString {
+			if _, isString := e.(string); !isString && e != nil {
+				got, _ := kindOf(e)
+				c.add(Diff{Path: join(path, key), Change: TypeChanged, Want: String, Got: got})
+			}
+			continue
+		}
+
+		c.compare(join(path, key), f.typ, e)
+	}
+
+	for name, f := range fields {
+		if _, ok := obj[name]; ok || f.optional || errorFields[name] {
+			continue
+		}
+
+		want, known := expected(f.typ)
+		if f.asString {
+			want, known = String, true
+		}
+		if known {
+			c.add(Diff{Path: join(path, name), Change: Missing, Want: want})
+		}
+	}
+}
+
+type field struct {
+	typ      reflect.Type
+	optional bool
+	asString bool
+}
+
+// fieldsOf returns the JSON fields of a struct by name, following the rules
+// of encoding/json for tags and embedded structs.
+func fieldsOf(t reflect.Type) map[string]field {
+	fields := map[string]field{}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := sf.Type
+		if sf.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, f := range fieldsOf(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = f
+					}
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		fields[name] = field{
+			typ:      ft,
+			optional: hasOption(opts, "omitempty") || hasOption(opts, "omitzero"),
+			asString: hasOption(opts, "string"),
+		}
+	}
+
+	return fields
+}
+
+func hasOption(opts, name string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expected returns the JSON type t decodes from. It returns false for
+// types that accept anything, such as interfaces and custom unmarshalers.
+func expected(t reflect.Type) (Kind, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return String, true
+	case t == numberType:
+		return Number, true
+	case keepsExtra(t):
+		// The models unmarshal themselves only to fill Extra.
+		return Object, true
+	case reflect.PointerTo(t).Implements(unmarshalerType):
+		return "", false
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return String, true
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return String, true
+	case reflect.Bool:
+		return Bool, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return Number, true
+	case reflect.Struct, reflect.Map:
+		return Object, true
+	case reflect.Slice, reflect.Array:
+		return Array, true
+	}
+
+	return "", false
+}
+
+// keepsExtra reports whether t is a struct that keeps unknown members in an
+// Extra field, as the lunchmoney models do.
+func keepsExtra(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	f, ok := t.FieldByName("Extra")
+	return ok && f.Type == extraType && f.Tag.Get("json") == "-"
+}
+
+// kindOf returns the JSON type of a value decoded with UseNumber, or false
+// for null.
+func kindOf(v any) (Kind, bool) {
+	switch v.(type) {
+	case string:
+		return String, true
+	case json.Number:
+		return Number, true
+	case bool:
+		return Bool, true
+	case map[string]any:
+		return Object, true
+	case []any:
+		return Array, true
+	}
+
+	return "", false
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
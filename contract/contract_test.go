@@ -0,0 +1,177 @@
+package contract
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney/cassette"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	type inner struct {
+		Name string `json:"name"`
+	}
+	type model struct {
+		ID       int              `json:"id"`
+		Amount   json.Number      `json:"amount"`
+		Count    int64            `json:"count,string"`
+		Note     *string          `json:"note,omitempty"`
+		Items    []inner          `json:"items"`
+		ByMonth  map[string]inner `json:"by_month"`
+		Anything any              `json:"anything"`
+		Ignored  string           `json:"-"`
+		Error    string           `json:"error"`
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "matches",
+			body: `{"id": 1, "amount": "1.50", "count": "3", "items": [{"name": "a"}], "by_month": {}, "anything": [1]}`,
+		},
+		{
+			name: "nulls fit anything",
+			body: `{"id": null, "amount": null, "count": null, "items": null, "by_month": null, "anything": null}`,
+		},
+		{
+			name: "added",
+			body: `{"id": 1, "amount": 1, "count": "3", "items": [{"name": "a", "new": 2}, {"name": "b", "new": 3}], "by_month": {"2024-01": {"name": "x", "extra": null}}, "anything": {}, "Ignored": ""}`,
+			want: []string{
+				": Ignored: added (string)",
+				": by_month.*.extra: added (null)",
+				": items[].new: added (number)",
+			},
+		},
+		{
+			name: "missing",
+			body: `{"items": [{}]}`,
+			want: []string{
+				": amount: missing (want number)",
+				": by_month: missing (want object)",
+				": count: missing (want string)",
+				": id: missing (want number)",
+				": items[].name: missing (want string)",
+			},
+		},
+		{
+			name: "type changed",
+			body: `{"id": "1", "amount": true, "count": 3, "items": {}, "by_month": {"a": []}, "anything": 1}`,
+			want: []string{
+				": amount: type changed: want number, got boolean",
+				": by_month.*: type changed: want object, got array",
+				": count: type changed: want string, got number",
+				": id: type changed: want number, got string",
+				": items: type changed: want array, got object",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := Compare([]byte(tt.body), model{})
+			require.NoError(t, err)
+
+			var got []string
+			for _, d := range diffs {
+				got = append(got, d.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// writeFixtures writes fixture files to a temporary directory and returns it.
+func writeFixtures(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, body := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644))
+	}
+
+	return dir
+}
+
+func TestCheckDir(t *testing.T) {
+	dir := writeFixtures(t, map[string]string{
+		"user.json": `{"user_name": "User 1", "user_email": "user-1@lunchmoney.dev", "user_id": "18328",
+			"account_id": 18221, "budget_name": "Family budget", "primary_currency": "usd"}`,
+		"tags.json": `[{"id": 1, "name": "Vacation", "description": "Trips away from home", "archived": false}]`,
+		"budgets.json": `[{"category_name": "Food", "category_id": 70910, "category_group_name": null, "group_id": 70909,
+			"is_income": false, "exclude_from_budget": false, "exclude_from_totals": false, "order": 1,
+			"data": {"2021-01-01": {"budget_month": "2021-01-01", "budget_amount": 100, "is_automated": false}},
+			"config": null}]`,
+	})
+	diffs, err := CheckDir(dir)
+	require.NoError(t, err)
+
+	var got []string
+	for _, d := range diffs {
+		got = append(got, d.String())
+	}
+	assert.Equal(t, []string{
+		"user: api_key_label: missing (want string)",
+		"user: user_id: type changed: want number, got string",
+		"tags: [].archived: added (boolean)",
+		"budgets: [].config: added (null)",
+		"budgets: [].data.*.is_automated: added (boolean)",
+	}, got)
+
+	_, err = CheckDir(t.TempDir())
+	assert.ErrorContains(t, err, "no fixtures")
+}
+
+// The fake server's fixtures are abridged from the API docs, so fields may
+// be missing from them, but they shouldn't have any the models don't know.
+func TestFixtures(t *testing.T) {
+	diffs, err := CheckDir("../lunchmoneytest/testdata")
+	require.NoError(t, err)
+
+	for _, d := range diffs {
+		assert.Equal(t, Missing, d.Change, d.String())
+	}
+}
+
+func TestCheckCassette(t *testing.T) {
+	c := &cassette.Cassette{Interactions: []*cassette.Interaction{
+		{
+			Request:  cassette.Request{Method: "GET", Path: "/v1/tags"},
+			Response: cassette.Response{Status: 200, Body: `[{"id": 1, "name": "a", "description": "", "archived": false}]`},
+		},
+		{
+			Request:  cassette.Request{Method: "GET", Path: "/v1/tags"},
+			Response: cassette.Response{Status: 200, Body: `[{"id": 2, "name": "b", "description": "", "archived": true}]`},
+		},
+		{
+			Request:  cassette.Request{Method: "GET", Path: "/v1/me"},
+			Response: cassette.Response{Status: 401, Body: `{"error": "Access token does not exist."}`},
+		},
+		{
+			Request:  cassette.Request{Method: "PUT", Path: "/v1/transactions/1"},
+			Response: cassette.Response{Status: 200, Body: `{"updated": true}`},
+		},
+	}}
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, os.WriteFile(path, b, 0o644))
+
+	diffs, err := CheckCassette(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Diff{{Endpoint: "tags", Path: "[].archived", Change: Added, Got: Bool}}, diffs)
+}
+
+func TestMonthQuery(t *testing.T) {
+	now := time.Date(2024, time.February, 10, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, map[string]string{"start_date": "2024-02-01", "end_date": "2024-02-29"},
+		monthQuery("start_date", "end_date")(now))
+	assert.Equal(t, map[string]string{"start_date": "2024-02-01"}, monthQuery("start_date")(now))
+}
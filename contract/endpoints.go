@@ -0,0 +1,163 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/cassette"
+)
+
+// Endpoint is an API endpoint and the model its response decodes into.
+type Endpoint struct {
+	// Name identifies the endpoint in diffs, and names its fixture file.
+	Name string
+	Path string
+	// Query, if set, returns the query parameters for a live request.
+	Query func(now time.Time) map[string]string
+	// Model is a zero value of the response type.
+	Model any
+}
+
+// Endpoints are the read endpoints the client decodes. Their names match the
+// fixtures in lunchmoneytest/testdata.
+var Endpoints = []Endpoint{
+	{Name: "user", Path: "/v1/me", Model: lunchmoney.User{}},
+	{Name: "transactions", Path: "/v1/transactions", Model: lunchmoney.TransactionsResponse{}},
+	{Name: "categories", Path: "/v1/categories", Model: lunchmoney.CategoriesResponse{}},
+	{Name: "tags", Path: "/v1/tags", Model: lunchmoney.TagsResponse{}},
+	{Name: "assets", Path: "/v1/assets", Model: lunchmoney.AssetsResponse{}},
+	{Name: "plaid", Path: "/v1/plaid_accounts", Model: lunchmoney.PlaidAccountsResponse{}},
+	{Name: "crypto", Path: "/v1/crypto", Model: lunchmoney.CryptoResponse{}},
+	{Name: "recurring", Path: "/v1/recurring_expenses", Query: monthQuery("start_date"), Model: lunchmoney.RecurringExpensesResponse{}},
+	{Name: "budgets", Path: "/v1/budgets", Query: monthQuery("start_date", "end_date"), Model: []*lunchmoney.Budget{}},
+}
+
+// monthQuery sets the start and, if given, end of the current month.
+func monthQuery(start string, end ...string) func(time.Time) map[string]string {
+	return func(now time.Time) map[string]string {
+		first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		q := map[string]string{start: first.Format(time.DateOnly)}
+		for _, e := range end {
+			q[e] = first.AddDate(0, 1, -1).Format(time.DateOnly)
+		}
+
+		return q
+	}
+}
+
+// Check compares a response from the endpoint against its model.
+func (e Endpoint) Check(body []byte) ([]Diff, error) {
+	diffs, err := Compare(body, e.Model)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.Name, err)
+	}
+
+	for i := range diffs {
+		diffs[i].Endpoint = e.Name
+	}
+
+	return diffs, nil
+}
+
+// CheckLive fetches every endpoint with client and checks the responses.
+func CheckLive(ctx context.Context, client *lunchmoney.Client) ([]Diff, error) {
+	var diffs []Diff
+	for _, e := range Endpoints {
+		var query map[string]string
+		if e.Query != nil {
+			query = e.Query(time.Now())
+		}
+
+		r, err := client.Get(ctx, e.Path, query)
+		if err != nil {
+			return nil, fmt.Errorf("get %s: %w", e.Name, err)
+		}
+
+		body, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", e.Name, err)
+		}
+
+		d, err := e.Check(body)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, d...)
+	}
+
+	return diffs, nil
+}
+
+// CheckDir checks the fixture files in dir, named after the endpoints, such
+// as transactions.json. Endpoints without a fixture are skipped, but it is
+// an error for dir to have none.
+func CheckDir(dir string) ([]Diff, error) {
+	var diffs []Diff
+	found := false
+	for _, e := range Endpoints {
+		body, err := os.ReadFile(filepath.Join(dir, e.Name+".json"))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+
+		d, err := e.Check(body)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, d...)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no fixtures in %s", dir)
+	}
+
+	return diffs, nil
+}
+
+// CheckCassette checks the successful GET responses recorded in the cassette
+// at path. Interactions with other endpoints are ignored.
+func CheckCassette(path string) ([]Diff, error) {
+	c, err := cassette.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := map[string]Endpoint{}
+	for _, e := range Endpoints {
+		byPath[e.Path] = e
+	}
+
+	var diffs []Diff
+	seen := map[Diff]bool{}
+	for _, in := range c.Interactions {
+		e, ok := byPath[in.Request.Path]
+		if !ok || in.Request.Method != http.MethodGet || in.Response.Status != http.StatusOK {
+			continue
+		}
+
+		d, err := e.Check([]byte(in.Response.Body))
+		if err != nil {
+			return nil, err
+		}
+		for _, diff := range d {
+			if !seen[diff] {
+				seen[diff] = true
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	return diffs, nil
+}
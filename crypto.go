@@ -0,0 +1,85 @@
+package lunchmoney
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+// CryptoResponse is the response from getting all crypto balances.
+type CryptoResponse struct {
+	Crypto []*Crypto `json:"crypto"`
+}
+
+// Crypto is a single LM crypto balance, either entered manually or synced
+// from an exchange or wallet.
+type Crypto struct {
+	ID              int64     `json:"id"`
+	ZaboAccountID   int64     `json:"zabo_account_id"`
+	Source          string    `json:"source"`
+	Name            string    `json:"name"`
+	DisplayName     string    `json:"display_name"`
+	Balance         string    `json:"balance"`
+	BalanceAsOf     time.Time `json:"balance_as_of"`
+	Currency        string    `json:"currency"`
+	Status          string    `json:"status"`
+	InstitutionName string    `json:"institution_name"`
+	CreatedAt       time.Time `json:"created_at"`
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Crypto) UnmarshalJSON(data []byte) error {
+	type plain Crypto
+	extra, err := unmarshalExtra(data, (*plain)(c))
+	c.Extra = extra
+
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Crypto) MarshalJSON() ([]byte, error) {
+	type plain Crypto
+
+	return marshalExtra(plain(c), c.Extra)
+}
+
+// ParsedAmount converts the crypto balance and currency into a money.Money object.
+// Returns an error if the balance cannot be parsed.
+func (c *Crypto) ParsedAmount() (*money.Money, error) {
+	return ParseCurrency(c.Balance, c.Currency)
+}
+
+// List retrieves all crypto balances from the Lunch Money API.
+// It returns a slice of Crypto objects or an error if the request fails.
+func (s *cryptoService) List(ctx context.Context) ([]*Crypto, error) {
+	options := map[string]string{}
+
+	body, err := s.c.Get(ctx, "/v1/crypto", options)
+	if err != nil {
+		return nil, fmt.Errorf("get crypto: %w", err)
+	}
+
+	resp := &CryptoResponse{}
+	if err := s.c.decode(ctx, "/v1/crypto", body, resp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if err := s.c.checkResponse(ctx, "/v1/crypto", resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Crypto, nil
+}
+
+// GetCrypto retrieves all crypto balances.
+//
+// Deprecated: Use Client.Crypto.List.
+func (c *Client) GetCrypto(ctx context.Context) ([]*Crypto, error) {
+	return c.CryptoService().List(ctx)
+}
@@ -0,0 +1,186 @@
+package lunchmoney
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Rhymond/go-money"
+)
+
+var (
+	// ErrCurrencyMismatch is returned when amounts in different currencies
+	// would have to be added together.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+
+	// ErrNoExchangeRate is returned when an amount isn't in the primary
+	// currency and the API didn't provide a converted value for it.
+	ErrNoExchangeRate = errors.New("no exchange rate")
+)
+
+// Conversion is a single amount converted into the primary currency.
+type Conversion struct {
+	Original *money.Money
+	Base     *money.Money
+	// Rate is the implied exchange rate, in primary currency units per unit
+	// of the original currency. It is zero when the original amount is zero.
+	Rate float64
+}
+
+// Converter converts amounts into a user's primary currency using the
+// to_base values returned by the API.
+type Converter struct {
+	Primary string
+}
+
+// NewConverter returns a Converter for the given primary currency code.
+func NewConverter(primary string) (*Converter, error) {
+	if primary == "" {
+		return nil, fmt.Errorf("primary currency is required")
+	}
+
+	return &Converter{Primary: strings.ToLower(primary)}, nil
+}
+
+// NewConverter looks up the user's primary currency and returns a Converter
+// for it.
+func (c *Client) NewConverter(ctx context.Context) (*Converter, error) {
+	u, err := c.UserService().Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	return NewConverter(u.PrimaryCurrency)
+}
+
+// Convert converts amount in currency into the primary currency. toBase is
+// the already converted value the API returned alongside the amount.
+func (cv *Converter) Convert(amount, currency string, toBase float64) (*Conversion, error) {
+	f, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not valid float: %w", amount, err)
+	}
+
+	if currency == "" {
+		currency = cv.Primary
+	}
+
+	ret := &Conversion{Original: newMoney(f, currency)}
+	switch {
+	case strings.EqualFold(currency, cv.Primary):
+		ret.Base = ret.Original
+		ret.Rate = 1
+	case toBase != 0:
+		ret.Base = newMoney(toBase, cv.Primary)
+		if f != 0 {
+			ret.Rate = toBase / f
+		}
+	case f == 0:
+		ret.Base = money.New(0, cv.Primary)
+	default:
+		return nil, fmt.Errorf("%s %s to %s: %w", amount, currency, cv.Primary, ErrNoExchangeRate)
+	}
+
+	return ret, nil
+}
+
+// Transaction converts a transaction's amount into the primary currency.
+func (cv *Converter) Transaction(t *Transaction) (*Conversion, error) {
+	ret, err := cv.Convert(t.Amount, t.Currency, t.ToBase)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %d: %w", t.ID, err)
+	}
+
+	return ret, nil
+}
+
+// Asset converts an asset's balance into the primary currency.
+func (cv *Converter) Asset(a *Asset) (*Conversion, error) {
+	ret, err := cv.Convert(a.Balance, a.Currency, a.ToBase)
+	if err != nil {
+		return nil, fmt.Errorf("asset %d: %w", a.ID, err)
+	}
+
+	return ret, nil
+}
+
+// PlaidAccount converts a Plaid account's balance into the primary currency.
+func (cv *Converter) PlaidAccount(p *PlaidAccount) (*Conversion, error) {
+	ret, err := cv.Convert(p.Balance, p.Currency, p.ToBase)
+	if err != nil {
+		return nil, fmt.Errorf("plaid account %d: %w", p.ID, err)
+	}
+
+	return ret, nil
+}
+
+// TransactionTotal is the sum of a set of transactions in the primary
+// currency, along with how each transaction was converted.
+type TransactionTotal struct {
+	Total       *money.Money
+	Conversions map[int64]*Conversion
+}
+
+// SumTransactions converts every transaction into the primary currency and
+// adds them up. It fails rather than skipping transactions that can't be
+// converted.
+func (cv *Converter) SumTransactions(txns []*Transaction) (*TransactionTotal, error) {
+	ret := &TransactionTotal{
+		Total:       money.New(0, cv.Primary),
+		Conversions: make(map[int64]*Conversion, len(txns)),
+	}
+
+	for _, t := range txns {
+		conv, err := cv.Transaction(t)
+		if err != nil {
+			return nil, err
+		}
+
+		total, err := Sum(ret.Total, conv.Base)
+		if err != nil {
+			return nil, err
+		}
+		ret.Total = total
+		ret.Conversions[t.ID] = conv
+	}
+
+	return ret, nil
+}
+
+// Sum adds amounts together, returning ErrCurrencyMismatch if they are not
+// all in the same currency.
+func Sum(amounts ...*money.Money) (*money.Money, error) {
+	if len(amounts) == 0 {
+		return nil, fmt.Errorf("nothing to sum")
+	}
+
+	ret, err := amounts[0].Add(amounts[1:]...)
+	if err != nil {
+		if errors.Is(err, money.ErrCurrencyMismatch) {
+			return nil, fmt.Errorf("%w: cannot add %s to %s", ErrCurrencyMismatch, mismatched(amounts), amounts[0].Currency().Code)
+		}
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func mismatched(amounts []*money.Money) string {
+	for _, a := range amounts[1:] {
+		if !a.SameCurrency(amounts[0]) {
+			return a.Currency().Code
+		}
+	}
+
+	return ""
+}
+
+// newMoney converts a float amount into money using the currency's minor
+// units, so JPY amounts aren't multiplied by 100.
+func newMoney(f float64, currency string) *money.Money {
+	fraction := money.New(0, currency).Currency().Fraction
+	return money.New(int64(math.Round(f*math.Pow10(fraction))), currency)
+}
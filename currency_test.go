@@ -0,0 +1,36 @@
+package lunchmoney
+
+import (
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConverterSumTransactions(t *testing.T) {
+	cv, err := NewConverter("USD")
+	require.NoError(t, err)
+
+	txns := []*Transaction{
+		{ID: 1, Amount: "4.3500", Currency: "usd", ToBase: 4.35},
+		{ID: 2, Amount: "10.0000", Currency: "cad", ToBase: 7.5},
+		{ID: 3, Amount: "1000.0000", Currency: "jpy", ToBase: 6.7},
+	}
+
+	got, err := cv.SumTransactions(txns)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1855), got.Total.Amount())
+	assert.Equal(t, "USD", got.Total.Currency().Code)
+	assert.InDelta(t, 0.75, got.Conversions[2].Rate, 0.0001)
+	assert.Equal(t, int64(1000), got.Conversions[3].Original.Amount())
+
+	_, err = cv.SumTransactions([]*Transaction{{ID: 4, Amount: "5.0000", Currency: "eur"}})
+	require.ErrorIs(t, err, ErrNoExchangeRate)
+}
+
+func TestSumMismatch(t *testing.T) {
+	_, err := Sum(money.New(100, "usd"), money.New(100, "cad"))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+	assert.Contains(t, err.Error(), "CAD")
+}
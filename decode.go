@@ -0,0 +1,226 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DecodeMode controls how strictly the client checks API responses.
+type DecodeMode int
+
+const (
+	// DecodeDefault ignores fields the models don't know about and fails on
+	// responses that don't validate.
+	DecodeDefault DecodeMode = iota
+	// DecodeStrict also fails on unknown fields, and doesn't patch up known
+	// quirks in the API's data. It is meant for contract tests.
+	DecodeStrict
+	// DecodeLenient turns type mismatches and validation failures into
+	// Warnings and returns whatever could be decoded. It is meant for
+	// production, where partial data beats none.
+	DecodeLenient
+)
+
+// Warning is a problem with a response that DecodeLenient let through.
+type Warning struct {
+	// Path is the API path of the response.
+	Path string
+	Err  error
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %v", w.Path, w.Err)
+}
+
+// Warnings collects the warnings of calls made with a context from
+// WithWarnings. It is safe for concurrent use.
+type Warnings struct {
+	mu   sync.Mutex
+	list []Warning
+}
+
+// List returns the warnings collected so far.
+func (w *Warnings) List() []Warning {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]Warning(nil), w.list...)
+}
+
+func (w *Warnings) add(warning Warning) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.list = append(w.list, warning)
+}
+
+type warningsKey struct{}
+
+// WithWarnings returns a context that collects the warnings of calls made
+// with it into w:
+//
+//	var warnings lunchmoney.Warnings
+//	budgets, err := client.Budgets.List(lunchmoney.WithWarnings(ctx, &warnings), filters)
+//	for _, w := range warnings.List() {
+//		log.Print(w)
+//	}
+//
+// Warnings are also logged to the client's Logger, if it has one.
+func WithWarnings(ctx context.Context, w *Warnings) context.Context {
+	return context.WithValue(ctx, warningsKey{}, w)
+}
+
+func (c *Client) warn(ctx context.Context, path string, err error) {
+	w := Warning{Path: path, Err: err}
+	if ws, ok := ctx.Value(warningsKey{}).(*Warnings); ok {
+		ws.add(w)
+	}
+
+	if c.Logger != nil {
+		c.Logger.LogAttrs(ctx, slog.LevelWarn, "lunchmoney response warning",
+			slog.String("path", path), slog.Any("error", err))
+	}
+}
+
+// decode decodes a response body according to the client's DecodeMode.
+func (c *Client) decode(ctx context.Context, path string, body io.Reader, v any) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	switch c.Decoding {
+	case DecodeStrict:
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(v); err != nil {
+			return err
+		}
+
+		// The models keep unknown members in Extra instead of failing.
+		if k, ok := unknownMember(data, reflect.TypeOf(v)); ok {
+			return fmt.Errorf("json: unknown field %q", k)
+		}
+
+		return nil
+	case DecodeLenient:
+		err := dec.Decode(v)
+		if !isTypeError(err) {
+			return err
+		}
+
+		// encoding/json gives up on a list at the first model with a type
+		// error, so decode the rest of the response piece by piece.
+		for _, err := range decodeEach(data, reflect.ValueOf(v).Elem()) {
+			if !isTypeError(err) {
+				return err
+			}
+			c.warn(ctx, path, err)
+		}
+
+		return nil
+	}
+
+	return dec.Decode(v)
+}
+
+func isTypeError(err error) bool {
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &typeErr)
+}
+
+// decodeEach decodes data into v one list element and one struct field at a
+// time, so an error in one doesn't stop the others. Types with their own
+// UnmarshalJSON, such as the models, are decoded whole.
+func decodeEach(data []byte, v reflect.Value) []error {
+	custom := reflect.PointerTo(v.Type()).Implements(reflect.TypeFor[json.Unmarshaler]())
+
+	switch {
+	case v.Kind() == reflect.Slice && !custom:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil || items == nil {
+			break
+		}
+
+		var errs []error
+		list := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			errs = append(errs, decodeEach(item, list.Index(i))...)
+		}
+		v.Set(list)
+
+		return errs
+	case v.Kind() == reflect.Struct && !custom:
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(data, &members); err != nil {
+			break
+		}
+
+		var errs []error
+		names := map[string]json.RawMessage{}
+		for k, raw := range members {
+			names[strings.ToLower(k)] = raw
+		}
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			tag := f.Tag.Get("json")
+			if !f.IsExported() || tag == "-" {
+				continue
+			}
+
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = f.Name
+			}
+			if raw, ok := names[strings.ToLower(name)]; ok {
+				errs = append(errs, decodeEach(raw, v.Field(i))...)
+			}
+		}
+
+		return errs
+	}
+
+	if err := json.Unmarshal(data, v.Addr().Interface()); err != nil {
+		return []error{err}
+	}
+
+	return nil
+}
+
+// checkResponse validates a decoded response according to the client's
+// DecodeMode.
+func (c *Client) checkResponse(ctx context.Context, path string, v any) error {
+	validate := validator.New()
+	err := validate.StructCtx(ctx, v)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	var invalidValidationError *validator.InvalidValidationError
+	switch {
+	case errors.As(err, &validationErrors):
+		err = fmt.Errorf("validating response: %s", validationErrors.Error())
+	case errors.As(err, &invalidValidationError):
+		err = fmt.Errorf("validating response (InvalidValidation): %s", invalidValidationError.Error())
+	default:
+		err = fmt.Errorf("validating response (%T): %w", err, err)
+	}
+
+	if c.Decoding == DecodeLenient {
+		c.warn(ctx, path, err)
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,110 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeModes(t *testing.T) {
+	responses := map[string]string{
+		// budget_month is missing from the first month.
+		"/v1/budgets": `[{
+			"category_id": 1,
+			"category_name": "Groceries",
+			"data": {
+				"2024-01-01": {"budget_amount": "100"},
+				"2024-02-01": {"budget_month": "2024-02-01", "budget_amount": "100"}
+			}
+		}]`,
+		"/v1/tags": `[{"id": 1, "name": "travel", "archived": false}]`,
+		// The first transaction's amount is a number, not a string.
+		"/v1/transactions": `{"transactions": [
+			{"id": 1, "date": "2024-01-02", "amount": 1, "currency": "usd", "to_base": 1},
+			{"id": 2, "date": "2024-01-03", "amount": "2.00", "currency": "usd", "to_base": 2}
+		]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(responses[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	filters := &BudgetFilters{StartDate: "2024-01-01", EndDate: "2024-02-29"}
+
+	tests := []struct {
+		name            string
+		mode            DecodeMode
+		budgetsErr      string
+		tagsErr         string
+		transactionsErr string
+		warnings        []string
+	}{
+		{
+			name:            "default",
+			mode:            DecodeDefault,
+			transactionsErr: "decode response",
+		},
+		{
+			name:            "strict",
+			mode:            DecodeStrict,
+			budgetsErr:      "validating response",
+			tagsErr:         `unknown field "archived"`,
+			transactionsErr: "decode response",
+		},
+		{
+			name:     "lenient",
+			mode:     DecodeLenient,
+			warnings: []string{"/v1/budgets", "/v1/transactions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient("test-key")
+			require.NoError(t, err)
+			client.Base, err = url.Parse(server.URL)
+			require.NoError(t, err)
+			client.Decoding = tt.mode
+
+			var warnings Warnings
+			ctx := WithWarnings(context.Background(), &warnings)
+
+			budgets, err := client.Budgets.List(ctx, filters)
+			if tt.budgetsErr != "" {
+				assert.ErrorContains(t, err, tt.budgetsErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "2024-01-01", budgets[0].Data["2024-01-01"].BudgetMonth)
+			}
+
+			_, err = client.Tags.List(ctx)
+			if tt.tagsErr != "" {
+				assert.ErrorContains(t, err, tt.tagsErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			transactions, err := client.Transactions.List(ctx, nil)
+			if tt.transactionsErr != "" {
+				assert.ErrorContains(t, err, tt.transactionsErr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, transactions, 2)
+				assert.Equal(t, "usd", transactions[0].Currency, "the rest of a bad transaction is kept")
+				assert.Equal(t, "2.00", transactions[1].Amount, "a bad transaction doesn't stop the list")
+			}
+
+			var paths []string
+			for _, w := range warnings.List() {
+				assert.Error(t, w.Err)
+				paths = append(paths, w.Path)
+			}
+			assert.Equal(t, tt.warnings, paths)
+		})
+	}
+}
@@ -21,7 +21,7 @@ func main() {
 		EndDate:   "2021-12-31",
 	}
 
-	ts, err := client.GetBudgets(ctx, opts)
+	ts, err := client.Budgets.List(ctx, opts)
 	if err != nil {
 		log.Fatalf("get err: %+v", err)
 	}
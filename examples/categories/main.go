@@ -16,7 +16,7 @@ func main() {
 		log.Fatalf("client err: %+v", err)
 	}
 
-	categories, err := client.GetCategories(ctx)
+	categories, err := client.Categories.List(ctx)
 	if err != nil {
 		log.Fatalf("get err: %+v", err)
 	}
@@ -27,7 +27,7 @@ func main() {
 
 	log.Printf("Fetching category details: %s\n", categories[0].Name)
 
-	category, err := client.GetCategory(ctx, categories[0].ID)
+	category, err := client.Categories.Get(ctx, categories[0].ID)
 	if err != nil {
 		log.Fatalf("get err: %+v", err)
 	}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/icco/lunchmoney"
+)
+
+func main() {
+	ctx := context.Background()
+	token := os.Getenv("LUNCHMONEY_TOKEN")
+	client, _ := lunchmoney.NewClient(token)
+	nw, err := client.NetWorth(ctx)
+	if err != nil {
+		log.Panicf("err: %+v", err)
+	}
+
+	log.Printf("net worth: %s (assets %s, liabilities %s)", nw.NetWorth.Display(), nw.Assets.Display(), nw.Liabilities.Display())
+	for k, v := range nw.ByType {
+		log.Printf("%s: %s", k, v.Display())
+	}
+}
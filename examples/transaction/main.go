@@ -12,7 +12,7 @@ func main() {
 	ctx := context.Background()
 	token := os.Getenv("LUNCHMONEY_TOKEN")
 	client, _ := lunchmoney.NewClient(token)
-	t, err := client.GetTransaction(ctx, 1, nil)
+	t, err := client.Transactions.Get(ctx, 1, nil)
 	if err != nil {
 		log.Panicf("err: %+v", err)
 	}
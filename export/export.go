@@ -0,0 +1,258 @@
+// Package export writes transactions to files people can use without Go:
+// CSV for spreadsheets, JSON Lines with a flat, typed schema that DuckDB and
+// Parquet converters read directly, and beancount and ledger journals for
+// plain-text accounting.
+package export
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Record is a flattened transaction. Every field is a scalar except Tags,
+// and field names match the column names used by the CSV writer.
+type Record struct {
+	ID                int64    `json:"id"`
+	Date              string   `json:"date"`
+	Payee             string   `json:"payee"`
+	OriginalName      string   `json:"original_name"`
+	Amount            float64  `json:"amount"`
+	Currency          string   `json:"currency"`
+	ToBase            float64  `json:"to_base"`
+	CategoryID        int64    `json:"category_id"`
+	CategoryName      string   `json:"category_name"`
+	CategoryGroupID   int64    `json:"category_group_id"`
+	CategoryGroupName string   `json:"category_group_name"`
+	IsIncome          bool     `json:"is_income"`
+	ExcludeFromBudget bool     `json:"exclude_from_budget"`
+	ExcludeFromTotals bool     `json:"exclude_from_totals"`
+	Notes             string   `json:"notes"`
+	Status            string   `json:"status"`
+	IsPending         bool     `json:"is_pending"`
+	AssetID           int64    `json:"asset_id"`
+	PlaidAccountID    int64    `json:"plaid_account_id"`
+	AccountName       string   `json:"account_name"`
+	InstitutionName   string   `json:"institution_name"`
+	RecurringID       int64    `json:"recurring_id"`
+	ParentID          int64    `json:"parent_id"`
+	GroupID           int64    `json:"group_id"`
+	ExternalID        string   `json:"external_id"`
+	Tags              []string `json:"tags"`
+}
+
+// Flatten converts a transaction into a Record.
+func Flatten(t *lunchmoney.Transaction) (*Record, error) {
+	amount, err := strconv.ParseFloat(t.Amount, 64)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %d: %q is not a valid amount: %w", t.ID, t.Amount, err)
+	}
+
+	tags := make([]string, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	institution := t.InstitutionName
+	if institution == "" {
+		institution = t.AssetInstitutionName
+	}
+
+	return &Record{
+		ID:                t.ID,
+		Date:              t.Date,
+		Payee:             t.Payee,
+		OriginalName:      t.OriginalName,
+		Amount:            amount,
+		Currency:          t.Currency,
+		ToBase:            t.ToBase,
+		CategoryID:        t.CategoryID,
+		CategoryName:      t.CategoryName,
+		CategoryGroupID:   t.CategoryGroupID,
+		CategoryGroupName: t.CategoryGroupName,
+		IsIncome:          t.IsIncome,
+		ExcludeFromBudget: t.ExcludeFromBudget,
+		ExcludeFromTotals: t.ExcludeFromTotals,
+		Notes:             t.Notes,
+		Status:            t.Status,
+		IsPending:         t.IsPending,
+		AssetID:           t.AssetID,
+		PlaidAccountID:    t.PlaidAccountID,
+		AccountName:       cmp.Or(t.AccountDisplayName, t.PlaidAccountDisplayName, t.AssetDisplayName, t.PlaidAccountName, t.AssetName),
+		InstitutionName:   institution,
+		RecurringID:       t.RecurringID,
+		ParentID:          t.ParentID,
+		GroupID:           t.GroupID,
+		ExternalID:        t.ExternalID,
+		Tags:              tags,
+	}, nil
+}
+
+// Column is a single exported column and its type, named as DuckDB types.
+type Column struct {
+	Name  string
+	Type  string
+	value func(*Record) string
+}
+
+func intCol(name string, f func(*Record) int64) Column {
+	return Column{Name: name, Type: "BIGINT", value: func(r *Record) string { return strconv.FormatInt(f(r), 10) }}
+}
+
+func strCol(name string, f func(*Record) string) Column {
+	return Column{Name: name, Type: "VARCHAR", value: f}
+}
+
+func boolCol(name string, f func(*Record) bool) Column {
+	return Column{Name: name, Type: "BOOLEAN", value: func(r *Record) string { return strconv.FormatBool(f(r)) }}
+}
+
+func floatCol(name string, f func(*Record) float64) Column {
+	return Column{Name: name, Type: "DOUBLE", value: func(r *Record) string { return strconv.FormatFloat(f(r), 'f', -1, 64) }}
+}
+
+// Columns lists every column in its stable export order.
+var Columns = []Column{
+	intCol("id", func(r *Record) int64 { return r.ID }),
+	{Name: "date", Type: "DATE", value: func(r *Record) string { return r.Date }},
+	strCol("payee", func(r *Record) string { return r.Payee }),
+	strCol("original_name", func(r *Record) string { return r.OriginalName }),
+	floatCol("amount", func(r *Record) float64 { return r.Amount }),
+	strCol("currency", func(r *Record) string { return r.Currency }),
+	floatCol("to_base", func(r *Record) float64 { return r.ToBase }),
+	intCol("category_id", func(r *Record) int64 { return r.CategoryID }),
+	strCol("category_name", func(r *Record) string { return r.CategoryName }),
+	intCol("category_group_id", func(r *Record) int64 { return r.CategoryGroupID }),
+	strCol("category_group_name", func(r *Record) string { return r.CategoryGroupName }),
+	boolCol("is_income", func(r *Record) bool { return r.IsIncome }),
+	boolCol("exclude_from_budget", func(r *Record) bool { return r.ExcludeFromBudget }),
+	boolCol("exclude_from_totals", func(r *Record) bool { return r.ExcludeFromTotals }),
+	strCol("notes", func(r *Record) string { return r.Notes }),
+	strCol("status", func(r *Record) string { return r.Status }),
+	boolCol("is_pending", func(r *Record) bool { return r.IsPending }),
+	intCol("asset_id", func(r *Record) int64 { return r.AssetID }),
+	intCol("plaid_account_id", func(r *Record) int64 { return r.PlaidAccountID }),
+	strCol("account_name", func(r *Record) string { return r.AccountName }),
+	strCol("institution_name", func(r *Record) string { return r.InstitutionName }),
+	intCol("recurring_id", func(r *Record) int64 { return r.RecurringID }),
+	intCol("parent_id", func(r *Record) int64 { return r.ParentID }),
+	intCol("group_id", func(r *Record) int64 { return r.GroupID }),
+	strCol("external_id", func(r *Record) string { return r.ExternalID }),
+	{Name: "tags", Type: "VARCHAR[]", value: func(r *Record) string { return strings.Join(r.Tags, ";") }},
+}
+
+// DefaultColumns are the columns written when none are chosen.
+var DefaultColumns = []string{"id", "date", "payee", "amount", "currency", "category_name", "category_group_name", "account_name", "notes", "tags"}
+
+// CSVWriter streams transactions as CSV with a header row. Tags are joined
+// with semicolons.
+type CSVWriter struct {
+	w       *csv.Writer
+	columns []Column
+	header  bool
+}
+
+// NewCSVWriter returns a writer for the named columns, in the order given.
+// With no columns, DefaultColumns are used.
+func NewCSVWriter(w io.Writer, columns ...string) (*CSVWriter, error) {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+
+	byName := make(map[string]Column, len(Columns))
+	for _, c := range Columns {
+		byName[c.Name] = c
+	}
+
+	cw := &CSVWriter{w: csv.NewWriter(w)}
+	for _, name := range columns {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		cw.columns = append(cw.columns, c)
+	}
+
+	return cw, nil
+}
+
+// Write writes a single transaction, preceded by the header on first use.
+func (cw *CSVWriter) Write(t *lunchmoney.Transaction) error {
+	if !cw.header {
+		names := make([]string, len(cw.columns))
+		for i, c := range cw.columns {
+			names[i] = c.Name
+		}
+
+		if err := cw.w.Write(names); err != nil {
+			return err
+		}
+		cw.header = true
+	}
+
+	r, err := Flatten(t)
+	if err != nil {
+		return err
+	}
+
+	row := make([]string, len(cw.columns))
+	for i, c := range cw.columns {
+		row[i] = c.value(r)
+	}
+
+	return cw.w.Write(row)
+}
+
+// WriteAll writes every transaction and flushes.
+func (cw *CSVWriter) WriteAll(txns []*lunchmoney.Transaction) error {
+	for _, t := range txns {
+		if err := cw.Write(t); err != nil {
+			return err
+		}
+	}
+
+	return cw.Flush()
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (cw *CSVWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// JSONLWriter streams transactions as JSON Lines of Record.
+type JSONLWriter struct {
+	enc *json.Encoder
+}
+
+// NewJSONLWriter returns a JSON Lines writer.
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{enc: json.NewEncoder(w)}
+}
+
+// Write writes a single transaction as one line.
+func (jw *JSONLWriter) Write(t *lunchmoney.Transaction) error {
+	r, err := Flatten(t)
+	if err != nil {
+		return err
+	}
+
+	return jw.enc.Encode(r)
+}
+
+// WriteAll writes every transaction.
+func (jw *JSONLWriter) WriteAll(txns []*lunchmoney.Transaction) error {
+	for _, t := range txns {
+		if err := jw.Write(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTransactions() []*lunchmoney.Transaction {
+	return []*lunchmoney.Transaction{
+		{
+			ID:                1,
+			Date:              "2023-01-05",
+			Payee:             "Blue Bottle",
+			Amount:            "4.5000",
+			Currency:          "usd",
+			CategoryName:      "Coffee",
+			CategoryGroupName: "Food",
+			AssetDisplayName:  "Checking",
+			Notes:             "latte, oat milk",
+			Tags:              []lunchmoney.Tag{{ID: 1, Name: "work"}, {ID: 2, Name: "travel"}},
+		},
+		{
+			ID:                      2,
+			Date:                    "2023-01-06",
+			Payee:                   "Payroll",
+			Amount:                  "-1000.0000",
+			Currency:                "usd",
+			IsIncome:                true,
+			PlaidAccountName:        "chk",
+			PlaidAccountDisplayName: "Bank Checking",
+		},
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	tests := map[string]struct {
+		columns []string
+		header  []string
+		first   []string
+	}{
+		"default": {
+			header: DefaultColumns,
+			first:  []string{"1", "2023-01-05", "Blue Bottle", "4.5", "usd", "Coffee", "Food", "Checking", "latte, oat milk", "work;travel"},
+		},
+		"selected": {
+			columns: []string{"date", "amount", "is_income", "account_name"},
+			header:  []string{"date", "amount", "is_income", "account_name"},
+			first:   []string{"2023-01-05", "4.5", "false", "Checking"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cw, err := NewCSVWriter(&buf, tc.columns...)
+			require.NoError(t, err)
+			require.NoError(t, cw.WriteAll(testTransactions()))
+
+			rows, err := csv.NewReader(&buf).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, rows, 3)
+			assert.Equal(t, tc.header, rows[0])
+			assert.Equal(t, tc.first, rows[1])
+		})
+	}
+
+	_, err := NewCSVWriter(&bytes.Buffer{}, "nope")
+	assert.Error(t, err)
+}
+
+func TestJSONLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONLWriter(&buf).WriteAll(testTransactions()))
+
+	var records []Record
+	s := bufio.NewScanner(&buf)
+	for s.Scan() {
+		var r Record
+		require.NoError(t, json.Unmarshal(s.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.Len(t, records, 2)
+
+	assert.Equal(t, 4.5, records[0].Amount)
+	assert.Equal(t, []string{"work", "travel"}, records[0].Tags)
+	assert.Equal(t, -1000.0, records[1].Amount)
+	assert.Equal(t, "Bank Checking", records[1].AccountName)
+	assert.Equal(t, []string{}, records[1].Tags)
+}
+
+func TestColumnsMatchRecord(t *testing.T) {
+	b, err := json.Marshal(&Record{})
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(b, &fields))
+
+	require.Len(t, Columns, len(fields))
+	for _, c := range Columns {
+		assert.Contains(t, fields, c.Name)
+	}
+}
+
+func TestFlattenBadAmount(t *testing.T) {
+	_, err := Flatten(&lunchmoney.Transaction{ID: 1, Amount: "abc"})
+	assert.Error(t, err)
+}
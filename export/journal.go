@@ -0,0 +1,365 @@
+package export
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Book is everything needed to write a plain-text accounting journal.
+//
+// Assets and Plaid accounts become "Assets:Institution:Name" or
+// "Liabilities:Institution:Name" accounts, and categories become
+// "Expenses:Group:Name" or "Income:Group:Name". Transactions without an
+// account are posted to Assets:Unassigned, and those without a category to
+// Expenses:Uncategorized or Income:Uncategorized.
+type Book struct {
+	Assets        []*lunchmoney.Asset
+	PlaidAccounts []*lunchmoney.PlaidAccount
+	Categories    []*lunchmoney.Category
+	Transactions  []*lunchmoney.Transaction
+	// SkipBalances omits balance assertions. Set it when Transactions
+	// doesn't cover each account's full history, or the assertions will fail.
+	SkipBalances bool
+}
+
+type posting struct {
+	account  string
+	amount   float64
+	currency string
+}
+
+type entry struct {
+	date      string
+	flag      string
+	payee     string
+	narration string
+	meta      [][2]string
+	postings  []posting
+}
+
+type assertion struct {
+	date    time.Time
+	account string
+	posting
+}
+
+type journal struct {
+	accounts   []string
+	entries    []*entry
+	assertions []*assertion
+	openDate   string
+}
+
+// WriteBeancount writes the book as a beancount ledger.
+func (b *Book) WriteBeancount(w io.Writer) error {
+	j, err := b.journal()
+	if err != nil {
+		return err
+	}
+
+	p := &printer{w: w}
+	for _, a := range j.accounts {
+		p.printf("%s open %s\n", j.openDate, a)
+	}
+
+	for _, e := range j.entries {
+		p.printf("\n%s %s %s %s\n", e.date, e.flag, quote(e.payee), quote(e.narration))
+		for _, m := range e.meta {
+			p.printf("  %s: %s\n", m[0], quote(m[1]))
+		}
+		for _, ps := range e.postings {
+			p.printf("  %s  %s %s\n", ps.account, formatFloat(ps.amount), ps.currency)
+		}
+	}
+
+	if len(j.assertions) > 0 {
+		p.printf("\n")
+	}
+	for _, a := range j.assertions {
+		// Beancount checks balances at the start of the day.
+		date := a.date.AddDate(0, 0, 1).Format("2006-01-02")
+		p.printf("%s balance %s  %s %s\n", date, a.account, formatFloat(a.amount), a.currency)
+	}
+
+	return p.err
+}
+
+// WriteLedger writes the book as a ledger-cli journal, which hledger also
+// reads.
+func (b *Book) WriteLedger(w io.Writer) error {
+	j, err := b.journal()
+	if err != nil {
+		return err
+	}
+
+	p := &printer{w: w}
+	for _, a := range j.accounts {
+		p.printf("account %s\n", a)
+	}
+
+	for _, e := range j.entries {
+		p.printf("\n%s %s %s\n", e.date, e.flag, oneLine(e.payee))
+		for _, m := range e.meta {
+			p.printf("    ; %s: %s\n", m[0], oneLine(m[1]))
+		}
+		if e.narration != "" {
+			p.printf("    ; %s\n", oneLine(e.narration))
+		}
+		for _, ps := range e.postings {
+			p.printf("    %s  %s %s\n", ps.account, formatFloat(ps.amount), ps.currency)
+		}
+	}
+
+	for _, a := range j.assertions {
+		p.printf("\n%s Balance assertion\n", a.date.Format("2006-01-02"))
+		p.printf("    %s  0 %s = %s %s\n", a.account, a.currency, formatFloat(a.amount), a.currency)
+	}
+
+	return p.err
+}
+
+// journal resolves accounts and categories and builds the entries common to
+// both formats.
+func (b *Book) journal() (*journal, error) {
+	j := &journal{}
+	used := map[string]bool{}
+	// name returns a unique account name, suffixing id on a collision.
+	name := func(root, institution, account string, id int64) string {
+		parts := []string{root}
+		if institution != "" {
+			parts = append(parts, accountPart(institution))
+		}
+		parts = append(parts, accountPart(account))
+
+		n := strings.Join(parts, ":")
+		if used[n] {
+			n = fmt.Sprintf("%s-%d", n, id)
+		}
+		used[n] = true
+		j.accounts = append(j.accounts, n)
+
+		return n
+	}
+	root := func(typ, subtype string) string {
+		if lunchmoney.IsLiability(typ, subtype) {
+			return "Liabilities"
+		}
+		return "Assets"
+	}
+	sign := func(typ, subtype string) float64 {
+		if lunchmoney.IsLiability(typ, subtype) {
+			return -1
+		}
+		return 1
+	}
+
+	assets := map[int64]string{}
+	for _, a := range b.Assets {
+		assets[a.ID] = name(root(a.TypeName, a.SubtypeName), a.InstitutionName, cmp.Or(a.DisplayName, a.Name), a.ID)
+		if err := j.assert(b, assets[a.ID], a.Status, a.Balance, a.Currency, a.BalanceAsOf, sign(a.TypeName, a.SubtypeName)); err != nil {
+			return nil, fmt.Errorf("asset %d: %w", a.ID, err)
+		}
+	}
+
+	plaid := map[int64]string{}
+	for _, p := range b.PlaidAccounts {
+		plaid[p.ID] = name(root(p.Type, p.Subtype), p.InstitutionName, cmp.Or(p.DisplayName, p.Name), p.ID)
+		if err := j.assert(b, plaid[p.ID], p.Status, p.Balance, p.Currency, p.BalanceLastUpdate, sign(p.Type, p.Subtype)); err != nil {
+			return nil, fmt.Errorf("plaid account %d: %w", p.ID, err)
+		}
+	}
+
+	byID := map[int64]*lunchmoney.Category{}
+	for _, c := range b.Categories {
+		byID[c.ID] = c
+	}
+	categoryName := func(income bool, group, category string) string {
+		parts := []string{"Expenses"}
+		if income {
+			parts[0] = "Income"
+		}
+		if group != "" {
+			parts = append(parts, accountPart(group))
+		}
+		return strings.Join(append(parts, accountPart(category)), ":")
+	}
+	categories := map[int64]string{}
+	for _, c := range b.Categories {
+		if c.IsGroup {
+			continue
+		}
+		group := ""
+		if g, ok := byID[c.GroupID]; ok {
+			group = g.Name
+		}
+		categories[c.ID] = categoryName(c.IsIncome, group, c.Name)
+	}
+
+	txns := make([]*lunchmoney.Transaction, 0, len(b.Transactions))
+	for _, t := range b.Transactions {
+		// Split parents are exported through their children.
+		if t.HasChildren {
+			continue
+		}
+		txns = append(txns, t)
+	}
+	sort.SliceStable(txns, func(i, k int) bool {
+		if txns[i].Date != txns[k].Date {
+			return txns[i].Date < txns[k].Date
+		}
+		return txns[i].ID < txns[k].ID
+	})
+
+	extra := map[string]bool{}
+	for _, t := range txns {
+		amount, err := strconv.ParseFloat(t.Amount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %q is not a valid amount: %w", t.ID, t.Amount, err)
+		}
+		currency := strings.ToUpper(t.Currency)
+
+		account, ok := assets[t.AssetID]
+		if t.PlaidAccountID != 0 {
+			account, ok = plaid[t.PlaidAccountID]
+		}
+		if !ok {
+			account = "Assets:Unassigned"
+		}
+
+		category, ok := categories[t.CategoryID]
+		if !ok {
+			category = categoryName(t.IsIncome, t.CategoryGroupName, cmp.Or(t.CategoryName, "Uncategorized"))
+		}
+
+		for _, a := range []string{account, category} {
+			if !used[a] && !extra[a] {
+				extra[a] = true
+				j.accounts = append(j.accounts, a)
+			}
+		}
+
+		e := &entry{
+			date:      t.Date,
+			flag:      "!",
+			payee:     cmp.Or(t.Payee, t.DisplayName, t.OriginalName),
+			narration: t.Notes,
+			meta:      [][2]string{{"lunchmoney_id", strconv.FormatInt(t.ID, 10)}},
+			postings: []posting{
+				// Lunch Money amounts are positive for money out of the
+				// account, which is money into the category.
+				{account: category, amount: amount, currency: currency},
+				{account: account, amount: -amount, currency: currency},
+			},
+		}
+		if t.Status == "cleared" {
+			e.flag = "*"
+		}
+		if t.ExternalID != "" {
+			e.meta = append(e.meta, [2]string{"external_id", t.ExternalID})
+		}
+		if len(t.Tags) > 0 {
+			tags := make([]string, len(t.Tags))
+			for i, tag := range t.Tags {
+				tags[i] = tag.Name
+			}
+			e.meta = append(e.meta, [2]string{"tags", strings.Join(tags, ", ")})
+		}
+
+		j.entries = append(j.entries, e)
+	}
+
+	sort.Strings(j.accounts)
+	sort.SliceStable(j.assertions, func(i, k int) bool {
+		return j.assertions[i].account < j.assertions[k].account
+	})
+
+	j.openDate = "1970-01-01"
+	if len(j.entries) > 0 {
+		j.openDate = j.entries[0].date
+	}
+	for _, a := range j.assertions {
+		if d := a.date.Format("2006-01-02"); d < j.openDate {
+			j.openDate = d
+		}
+	}
+
+	return j, nil
+}
+
+// assert records a balance assertion for an account, unless balances are
+// skipped or the account has no usable balance.
+func (j *journal) assert(b *Book, account, status, balance, currency string, asOf time.Time, sign float64) error {
+	if b.SkipBalances || balance == "" || asOf.IsZero() || lunchmoney.IsStale(status) {
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(balance, 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid balance: %w", balance, err)
+	}
+
+	j.assertions = append(j.assertions, &assertion{
+		date:    asOf.UTC(),
+		account: account,
+		posting: posting{amount: sign * f, currency: strings.ToUpper(currency)},
+	})
+
+	return nil
+}
+
+// accountPart turns a name into an account component both beancount and
+// ledger accept: words are capitalized and joined with dashes, and anything
+// other than letters and digits is dropped.
+func accountPart(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+
+	if len(words) == 0 {
+		return "Unknown"
+	}
+
+	return strings.Join(words, "-")
+}
+
+func formatFloat(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func quote(s string) string {
+	return strconv.Quote(oneLine(s))
+}
+
+// printer remembers the first write error so callers can check once.
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (p *printer) printf(format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBook() *Book {
+	asOf := time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC)
+	return &Book{
+		Assets: []*lunchmoney.Asset{
+			{ID: 1, TypeName: "cash", Name: "Everyday Checking", InstitutionName: "Bank of Me", Balance: "995.5000", Currency: "usd", Status: "active", BalanceAsOf: asOf},
+			{ID: 2, TypeName: "cash", Name: "Old Savings", Balance: "10.0000", Currency: "usd", Status: "closed", BalanceAsOf: asOf},
+		},
+		PlaidAccounts: []*lunchmoney.PlaidAccount{
+			{ID: 3, Type: "credit", Name: "Freedom", InstitutionName: "Chase", Balance: "20.0000", Currency: "usd", Status: "active", BalanceLastUpdate: asOf},
+		},
+		Categories: []*lunchmoney.Category{
+			{ID: 10, Name: "Food & Drink", IsGroup: true},
+			{ID: 11, Name: "coffee shops", GroupID: 10},
+			{ID: 12, Name: "Salary", IsIncome: true},
+		},
+		Transactions: []*lunchmoney.Transaction{
+			{ID: 101, Date: "2023-01-06", Payee: "Employer", Amount: "-1000.0000", Currency: "usd", CategoryID: 12, AssetID: 1, Status: "cleared"},
+			{ID: 100, Date: "2023-01-05", Payee: `Blue "Bottle"`, Amount: "4.5000", Currency: "usd", CategoryID: 11, AssetID: 1, Status: "cleared", Notes: "latte", Tags: []lunchmoney.Tag{{Name: "work"}, {Name: "travel"}}},
+			{ID: 102, Date: "2023-01-07", Payee: "Bookshop", Amount: "20.0000", Currency: "usd", PlaidAccountID: 3, Status: "uncleared"},
+		},
+	}
+}
+
+func TestWriteBeancount(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testBook().WriteBeancount(&buf))
+
+	want := `2023-01-05 open Assets:Bank-Of-Me:Everyday-Checking
+2023-01-05 open Assets:Old-Savings
+2023-01-05 open Expenses:Food-Drink:Coffee-Shops
+2023-01-05 open Expenses:Uncategorized
+2023-01-05 open Income:Salary
+2023-01-05 open Liabilities:Chase:Freedom
+
+2023-01-05 * "Blue \"Bottle\"" "latte"
+  lunchmoney_id: "100"
+  tags: "work, travel"
+  Expenses:Food-Drink:Coffee-Shops  4.5 USD
+  Assets:Bank-Of-Me:Everyday-Checking  -4.5 USD
+
+2023-01-06 * "Employer" ""
+  lunchmoney_id: "101"
+  Income:Salary  -1000 USD
+  Assets:Bank-Of-Me:Everyday-Checking  1000 USD
+
+2023-01-07 ! "Bookshop" ""
+  lunchmoney_id: "102"
+  Expenses:Uncategorized  20 USD
+  Liabilities:Chase:Freedom  -20 USD
+
+2023-02-01 balance Assets:Bank-Of-Me:Everyday-Checking  995.5 USD
+2023-02-01 balance Liabilities:Chase:Freedom  -20 USD
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestWriteLedger(t *testing.T) {
+	b := testBook()
+	b.SkipBalances = true
+
+	var buf bytes.Buffer
+	require.NoError(t, b.WriteLedger(&buf))
+
+	want := `account Assets:Bank-Of-Me:Everyday-Checking
+account Assets:Old-Savings
+account Expenses:Food-Drink:Coffee-Shops
+account Expenses:Uncategorized
+account Income:Salary
+account Liabilities:Chase:Freedom
+
+2023-01-05 * Blue "Bottle"
+    ; lunchmoney_id: 100
+    ; tags: work, travel
+    ; latte
+    Expenses:Food-Drink:Coffee-Shops  4.5 USD
+    Assets:Bank-Of-Me:Everyday-Checking  -4.5 USD
+
+2023-01-06 * Employer
+    ; lunchmoney_id: 101
+    Income:Salary  -1000 USD
+    Assets:Bank-Of-Me:Everyday-Checking  1000 USD
+
+2023-01-07 ! Bookshop
+    ; lunchmoney_id: 102
+    Expenses:Uncategorized  20 USD
+    Liabilities:Chase:Freedom  -20 USD
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestLedgerBalanceAssertion(t *testing.T) {
+	b := testBook()
+	b.Transactions = nil
+
+	var buf bytes.Buffer
+	require.NoError(t, b.WriteLedger(&buf))
+	assert.Contains(t, buf.String(), "2023-01-31 Balance assertion\n    Liabilities:Chase:Freedom  0 USD = -20 USD\n")
+}
+
+func TestAccountPart(t *testing.T) {
+	tests := map[string]string{
+		"Bank of Me":    "Bank-Of-Me",
+		"401k":          "401k",
+		"Café Crème":    "Café-Crème",
+		"  --  ":        "Unknown",
+		"AT&T Wireless": "AT-T-Wireless",
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, accountPart(in), in)
+	}
+}
+
+func TestDuplicateAccountNames(t *testing.T) {
+	b := &Book{Assets: []*lunchmoney.Asset{
+		{ID: 1, TypeName: "cash", Name: "Cash"},
+		{ID: 2, TypeName: "cash", Name: "cash"},
+	}}
+
+	j, err := b.journal()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Assets:Cash", "Assets:Cash-2"}, j.accounts)
+}
@@ -0,0 +1,191 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// The models keep the members of the API's JSON that they have no field for
+// in an Extra map, so fields the API adds can be used before this package
+// knows about them, and survive being marshaled again:
+//
+//	var archived bool
+//	if raw, ok := tag.Extra["archived"]; ok {
+//		err := json.Unmarshal(raw, &archived)
+//	}
+//
+// Each model's UnmarshalJSON and MarshalJSON methods implement this by
+// calling unmarshalExtra and marshalExtra on a copy of its type without the
+// methods.
+
+var extraType = reflect.TypeFor[map[string]json.RawMessage]()
+
+// fieldTypes caches the types of a struct's fields by type and lower cased
+// JSON name.
+var fieldTypes sync.Map
+
+// jsonFields returns the types of the fields of struct type t by the lower
+// cased names encoding/json matches against. Matching is case insensitive.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	if fields, ok := fieldTypes.Load(t); ok {
+		return fields.(map[string]reflect.Type)
+	}
+
+	fields := map[string]reflect.Type{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	fieldTypes.Store(t, fields)
+
+	return fields
+}
+
+// unmarshalExtra decodes b into v, a pointer to a struct, and returns the
+// members of b that none of its fields took, or nil if there are none.
+func unmarshalExtra(b []byte, v any) (map[string]json.RawMessage, error) {
+	err := json.Unmarshal(b, v)
+	if err != nil && !isTypeError(err) {
+		return nil, err
+	}
+
+	var members map[string]json.RawMessage
+	if json.Unmarshal(b, &members) != nil {
+		return nil, err
+	}
+
+	fields := jsonFields(reflect.TypeOf(v).Elem())
+	var extra map[string]json.RawMessage
+	for k, raw := range members {
+		if _, ok := fields[strings.ToLower(k)]; ok {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[k] = raw
+	}
+
+	// A type error leaves the rest of v decoded, so Extra is still set.
+	return extra, err
+}
+
+// marshalExtra encodes v, a struct, with the members of extra added after
+// its fields. Members that have a field are skipped.
+func marshalExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	fields := jsonFields(reflect.TypeOf(v))
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		if _, ok := fields[strings.ToLower(k)]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var buf bytes.Buffer
+	buf.Write(b[:len(b)-1])
+	for _, k := range keys {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid(extra[k]) {
+			return nil, fmt.Errorf("extra member %q is not valid JSON", k)
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(extra[k])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// unknownMember returns the first member of data, at any depth, that has no
+// field in t, for DecodeStrict. DisallowUnknownFields can't find these
+// itself: encoding/json doesn't apply it inside an UnmarshalJSON method, so
+// it misses the models and everything nested in them.
+func unknownMember(data []byte, t reflect.Type) (string, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	custom := reflect.PointerTo(t).Implements(reflect.TypeFor[json.Unmarshaler]())
+	if custom && !hasExtra(t) {
+		return "", false
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return "", false
+		}
+		for _, item := range items {
+			if k, ok := unknownMember(item, t.Elem()); ok {
+				return k, true
+			}
+		}
+	case reflect.Map:
+		var members map[string]json.RawMessage
+		if json.Unmarshal(data, &members) != nil {
+			return "", false
+		}
+		for _, k := range slices.Sorted(maps.Keys(members)) {
+			if k, ok := unknownMember(members[k], t.Elem()); ok {
+				return k, true
+			}
+		}
+	case reflect.Struct:
+		var members map[string]json.RawMessage
+		if json.Unmarshal(data, &members) != nil {
+			return "", false
+		}
+		fields := jsonFields(t)
+		for _, k := range slices.Sorted(maps.Keys(members)) {
+			ft, ok := fields[strings.ToLower(k)]
+			if !ok {
+				return k, true
+			}
+			if k, ok := unknownMember(members[k], ft); ok {
+				return k, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// hasExtra reports whether t is a model that keeps unknown members in Extra.
+func hasExtra(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	f, ok := t.FieldByName("Extra")
+	return ok && f.Type == extraType
+}
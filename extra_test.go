@@ -0,0 +1,192 @@
+package lunchmoney
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtra(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		model any
+		extra map[string]json.RawMessage
+	}{
+		{
+			name:  "tag",
+			in:    `{"id": 1, "name": "Vacation", "description": "", "archived": true}`,
+			model: &Tag{},
+			extra: map[string]json.RawMessage{"archived": json.RawMessage("true")},
+		},
+		{
+			name:  "transaction with nested tag",
+			in:    `{"id": 7, "payee": "Blue Bottle", "tags": [{"id": 1, "name": "coffee", "color": "brown"}], "files": [], "plaid_metadata": null}`,
+			model: &Transaction{},
+			extra: map[string]json.RawMessage{"files": json.RawMessage("[]")},
+		},
+		{
+			name:  "user without unknown fields",
+			in:    `{"user_id": 1, "user_name": "User 1"}`,
+			model: &User{},
+		},
+		{
+			name:  "case insensitive match",
+			in:    `{"ID": 3, "Name": "Savings", "logo_url": "https://example.com/logo.png"}`,
+			model: &Asset{},
+			extra: map[string]json.RawMessage{"logo_url": json.RawMessage(`"https://example.com/logo.png"`)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, json.Unmarshal([]byte(tt.in), tt.model))
+
+			var extra map[string]json.RawMessage
+			switch m := tt.model.(type) {
+			case *Tag:
+				extra = m.Extra
+			case *Transaction:
+				extra = m.Extra
+				require.Len(t, m.Tags, 1)
+				assert.Equal(t, json.RawMessage(`"brown"`), m.Tags[0].Extra["color"])
+			case *User:
+				extra = m.Extra
+			case *Asset:
+				extra = m.Extra
+				assert.Equal(t, int64(3), m.ID)
+			}
+			assert.Equal(t, tt.extra, extra)
+
+			// Extra survives a round trip.
+			b, err := json.Marshal(tt.model)
+			require.NoError(t, err)
+			var got map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(b, &got))
+			for k, v := range tt.extra {
+				assert.JSONEq(t, string(v), string(got[k]), k)
+			}
+			assert.NotContains(t, got, "Extra")
+		})
+	}
+}
+
+func TestExtraMarshal(t *testing.T) {
+	tag := Tag{ID: 1, Name: "a", Extra: map[string]json.RawMessage{
+		"name":     json.RawMessage(`"ignored"`),
+		"archived": json.RawMessage(`false`),
+	}}
+	b, err := json.Marshal(tag)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1, "name": "a", "description": "", "archived": false}`, string(b))
+
+	tag.Extra = map[string]json.RawMessage{"bad": json.RawMessage(`{`)}
+	_, err = json.Marshal(tag)
+	assert.Error(t, err)
+}
+
+func TestRawResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/tags" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error": "oops"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"user_id": 1, "user_name": "User 1", "beta": {"flag": true}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+
+	var raw RawResponses
+	_, ok := raw.Last()
+	assert.False(t, ok)
+
+	ctx := WithRawResponses(context.Background(), &raw)
+	user, err := client.User.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "User 1", user.UserName)
+	assert.JSONEq(t, `{"flag": true}`, string(user.Extra["beta"]))
+
+	_, err = client.Tags.List(ctx)
+	require.Error(t, err)
+
+	_, err = client.User.Get(context.Background())
+	require.NoError(t, err)
+
+	list := raw.List()
+	require.Len(t, list, 2, "only calls with the context are captured")
+	assert.Equal(t, "/v1/me", list[0].Path)
+	assert.Equal(t, http.MethodGet, list[0].Method)
+	assert.Equal(t, http.StatusOK, list[0].Status)
+	assert.JSONEq(t, `{"user_id": 1, "user_name": "User 1", "beta": {"flag": true}}`, string(list[0].Body))
+
+	last, ok := raw.Last()
+	require.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, last.Status)
+	assert.Equal(t, `{"error": "oops"}`, string(last.Body))
+}
+
+func TestDecodeStrictNested(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  string
+	}{
+		{
+			name: "known fields",
+			body: `[{"category_id": 1, "data": {"2024-01-01": {"budget_month": "2024-01-01", "budget_amount": "100"}}}]`,
+		},
+		{
+			name: "unknown budget data field",
+			body: `[{"category_id": 1, "data": {"2024-01-01": {"budget_month": "2024-01-01", "is_automated": true}}}]`,
+			err:  `unknown field "is_automated"`,
+		},
+		{
+			name: "unknown recurring item field",
+			body: `[{"category_id": 1, "recurring": {"sum": 1, "list": [{"payee": "Gym", "cadence": "monthly"}]}}]`,
+			err:  `unknown field "cadence"`,
+		},
+		{
+			name: "unknown budget field",
+			body: `[{"category_id": 1, "rollover": 5}]`,
+			err:  `unknown field "rollover"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-key")
+			require.NoError(t, err)
+			client.Base, err = url.Parse(server.URL)
+			require.NoError(t, err)
+			filters := &BudgetFilters{StartDate: "2024-01-01", EndDate: "2024-01-31"}
+
+			_, err = client.Budgets.List(context.Background(), filters)
+			require.NoError(t, err, "the default mode accepts unknown fields")
+
+			client.Decoding = DecodeStrict
+			_, err = client.Budgets.List(context.Background(), filters)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Package history records account balances over time so net worth can be
+// charted. The Lunch Money API only returns current balances, so each call to
+// Recorder.Record appends what it sees to a Store, keyed by account and the
+// time the balance was last updated. Accounts that stop being reported are
+// recorded as closed, so the series stop counting them.
+package history
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/icco/lunchmoney"
+)
+
+// Snapshot is a single account balance at a point in time.
+type Snapshot struct {
+	RecordedAt  time.Time `json:"recorded_at"`
+	BalanceAsOf time.Time `json:"balance_as_of"`
+	Source      string    `json:"source"`
+	AccountID   int64     `json:"account_id"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Institution string    `json:"institution"`
+	Liability   bool      `json:"liability"`
+	Balance     string    `json:"balance"`
+	Currency    string    `json:"currency"`
+	// Base is the balance in BaseCurrency, empty if it couldn't be converted.
+	Base         string `json:"base,omitempty"`
+	BaseCurrency string `json:"base_currency"`
+	// Status is empty for a recorded balance. A stale status, such as the
+	// "closed" Record writes for an account that is no longer reported,
+	// ends the account: the series don't carry its balance any further.
+	Status string `json:"status,omitempty"`
+}
+
+// at is when the balance was last updated, or when it was fetched if the API
+// didn't say.
+func (s *Snapshot) at() time.Time {
+	if s.BalanceAsOf.IsZero() {
+		return s.RecordedAt.UTC()
+	}
+
+	return s.BalanceAsOf.UTC()
+}
+
+func (s *Snapshot) account() string {
+	return fmt.Sprintf("%s/%d", s.Source, s.AccountID)
+}
+
+func (s *Snapshot) key() string {
+	return fmt.Sprintf("%s/%s", s.account(), s.at().Format(time.RFC3339Nano))
+}
+
+func (s *Snapshot) closed() bool {
+	return lunchmoney.IsStale(s.Status)
+}
+
+// contribution is the signed base balance of the snapshot, or nil if it has
+// none.
+func (s *Snapshot) contribution() (*money.Money, error) {
+	if s.Base == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(s.Base, 64)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s has bad base %q: %w", s.key(), s.Base, err)
+	}
+
+	if s.Liability {
+		f = -f
+	}
+
+	fraction := money.New(0, s.BaseCurrency).Currency().Fraction
+	return money.New(int64(math.Round(f*math.Pow10(fraction))), s.BaseCurrency), nil
+}
+
+// Store persists snapshots.
+type Store interface {
+	Append(ctx context.Context, snaps []Snapshot) error
+	Load(ctx context.Context) ([]Snapshot, error)
+}
+
+// Recorder writes net worth snapshots to a Store.
+type Recorder struct {
+	Store Store
+	// Now is used for Snapshot.RecordedAt, defaulting to time.Now.
+	Now func() time.Time
+}
+
+// NewRecorder returns a Recorder writing to store.
+func NewRecorder(store Store) *Recorder {
+	return &Recorder{Store: store, Now: time.Now}
+}
+
+// RecordClient fetches the current net worth and records it.
+func (r *Recorder) RecordClient(ctx context.Context, c *lunchmoney.Client) (int, error) {
+	nw, err := c.NetWorth(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Record(ctx, nw)
+}
+
+// Record stores every account in the report whose balance hasn't already been
+// recorded for the same BalanceAsOf. An account without a BalanceAsOf is
+// stored when its balance differs from the last one recorded. Accounts
+// recorded before but missing from the report, because they were closed,
+// deactivated or deleted, get a closed snapshot. It returns how many
+// snapshots were written.
+func (r *Recorder) Record(ctx context.Context, nw *lunchmoney.NetWorthReport) (int, error) {
+	existing, err := r.Store.Load(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("load snapshots: %w", err)
+	}
+
+	seen := make(map[string]bool, len(existing))
+	last := map[string]Snapshot{}
+	var order []string
+	for _, s := range existing {
+		seen[s.key()] = true
+
+		l, ok := last[s.account()]
+		if !ok {
+			order = append(order, s.account())
+		}
+		if !ok || !s.at().Before(l.at()) {
+			last[s.account()] = s
+		}
+	}
+
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	recordedAt := now().UTC()
+
+	var snaps []Snapshot
+	reported := map[string]bool{}
+	accounts := append(append([]*lunchmoney.NetWorthAccount{}, nw.Accounts...), nw.Unconverted...)
+	for _, a := range accounts {
+		s := Snapshot{
+			RecordedAt:   recordedAt,
+			BalanceAsOf:  a.BalanceAsOf.UTC(),
+			Source:       a.Source,
+			AccountID:    a.ID,
+			Name:         a.Name,
+			Type:         a.Type,
+			Institution:  a.Institution,
+			Liability:    a.Liability,
+			Balance:      formatMoney(a.Balance),
+			Currency:     a.Balance.Currency().Code,
+			BaseCurrency: nw.Currency,
+		}
+		if a.Base != nil {
+			s.Base = formatMoney(a.Base)
+		}
+		reported[s.account()] = true
+
+		if a.BalanceAsOf.IsZero() {
+			l, ok := last[s.account()]
+			if ok && !l.closed() && l.Balance == s.Balance && l.Currency == s.Currency {
+				continue
+			}
+		} else if seen[s.key()] {
+			continue
+		}
+		seen[s.key()] = true
+		snaps = append(snaps, s)
+	}
+
+	for _, account := range order {
+		l := last[account]
+		if reported[account] || l.closed() {
+			continue
+		}
+
+		snaps = append(snaps, Snapshot{
+			RecordedAt:   recordedAt,
+			BalanceAsOf:  recordedAt,
+			Source:       l.Source,
+			AccountID:    l.AccountID,
+			Name:         l.Name,
+			Type:         l.Type,
+			Institution:  l.Institution,
+			Liability:    l.Liability,
+			Currency:     l.Currency,
+			BaseCurrency: nw.Currency,
+			Status:       "closed",
+		})
+	}
+
+	if len(snaps) == 0 {
+		return 0, nil
+	}
+
+	if err := r.Store.Append(ctx, snaps); err != nil {
+		return 0, fmt.Errorf("append snapshots: %w", err)
+	}
+
+	return len(snaps), nil
+}
+
+func formatMoney(m *money.Money) string {
+	return strconv.FormatFloat(m.AsMajorUnits(), 'f', m.Currency().Fraction, 64)
+}
+
+// Point is a single value in a time series, in the base currency.
+type Point struct {
+	Date  time.Time
+	Value *money.Money
+}
+
+// AccountSeries returns the base balance of a single account each day it
+// changed. Liabilities are negative.
+func AccountSeries(snaps []Snapshot, source string, id int64) ([]Point, error) {
+	return series(snaps, func(s *Snapshot) bool {
+		return s.Source == source && s.AccountID == id
+	})
+}
+
+// TypeSeries returns the total base balance of all accounts of the given type
+// each day any of them changed.
+func TypeSeries(snaps []Snapshot, typ string) ([]Point, error) {
+	return series(snaps, func(s *Snapshot) bool {
+		return s.Type == typ
+	})
+}
+
+// TotalSeries returns net worth each day any account changed.
+func TotalSeries(snaps []Snapshot) ([]Point, error) {
+	return series(snaps, func(*Snapshot) bool { return true })
+}
+
+// series walks matching snapshots in BalanceAsOf order, falling back to
+// RecordedAt when BalanceAsOf is unset, carrying each account's latest
+// balance forward until the account is closed, and emits the running total
+// once per day. Other snapshots without a base balance are ignored.
+func series(snaps []Snapshot, match func(*Snapshot) bool) ([]Point, error) {
+	var filtered []Snapshot
+	for _, s := range snaps {
+		if (s.Base != "" || s.closed()) && match(&s) {
+			filtered = append(filtered, s)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].at().Before(filtered[j].at())
+	})
+
+	latest := map[string]*money.Money{}
+	var ret []Point
+	for i, s := range filtered {
+		if s.closed() {
+			delete(latest, s.account())
+		} else {
+			m, err := s.contribution()
+			if err != nil {
+				return nil, err
+			}
+			latest[s.account()] = m
+		}
+
+		day := truncateDay(s.at())
+		if i+1 < len(filtered) && truncateDay(filtered[i+1].at()).Equal(day) {
+			continue
+		}
+
+		total := money.New(0, s.BaseCurrency)
+		for _, v := range latest {
+			var err error
+			if total, err = lunchmoney.Sum(total, v); err != nil {
+				return nil, err
+			}
+		}
+		ret = append(ret, Point{Date: day, Value: total})
+	}
+
+	return ret, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
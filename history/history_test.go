@@ -0,0 +1,132 @@
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func report(day int, checking, card int64) *lunchmoney.NetWorthReport {
+	asOf := time.Date(2023, 1, day, 12, 0, 0, 0, time.UTC)
+	return &lunchmoney.NetWorthReport{
+		Currency: "usd",
+		Accounts: []*lunchmoney.NetWorthAccount{
+			{Source: lunchmoney.SourceAsset, ID: 1, Name: "Checking", Type: "cash", Balance: money.New(checking, "usd"), Base: money.New(checking, "usd"), BalanceAsOf: asOf},
+			{Source: lunchmoney.SourcePlaid, ID: 2, Name: "Card", Type: "credit", Liability: true, Balance: money.New(card, "usd"), Base: money.New(card, "usd"), BalanceAsOf: asOf},
+		},
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	store := NewJSONLStore(filepath.Join(t.TempDir(), "balances.jsonl"))
+	r := NewRecorder(store)
+
+	n, err := r.Record(ctx, report(1, 100000, 25000))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// Same BalanceAsOf is not recorded twice.
+	n, err = r.Record(ctx, report(1, 100000, 25000))
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = r.Record(ctx, report(3, 120000, 10000))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	snaps, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, snaps, 4)
+	assert.Equal(t, "1000.00", snaps[0].Balance)
+
+	total, err := TotalSeries(snaps)
+	require.NoError(t, err)
+	require.Len(t, total, 2)
+	assert.Equal(t, int64(75000), total[0].Value.Amount())
+	assert.Equal(t, int64(110000), total[1].Value.Amount())
+	assert.Equal(t, time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC), total[1].Date)
+
+	card, err := AccountSeries(snaps, lunchmoney.SourcePlaid, 2)
+	require.NoError(t, err)
+	require.Len(t, card, 2)
+	assert.Equal(t, int64(-10000), card[1].Value.Amount())
+
+	cash, err := TypeSeries(snaps, "cash")
+	require.NoError(t, err)
+	require.Len(t, cash, 2)
+	assert.Equal(t, int64(120000), cash[1].Value.Amount())
+}
+
+func TestRecorderClosedAccount(t *testing.T) {
+	ctx := context.Background()
+	store := NewJSONLStore(filepath.Join(t.TempDir(), "balances.jsonl"))
+	r := NewRecorder(store)
+	r.Now = func() time.Time { return time.Date(2023, 1, 3, 18, 0, 0, 0, time.UTC) }
+
+	_, err := r.Record(ctx, report(1, 100000, 25000))
+	require.NoError(t, err)
+
+	// The card was closed, so NetWorth no longer reports it.
+	nw := report(3, 120000, 0)
+	nw.Accounts = nw.Accounts[:1]
+	n, err := r.Record(ctx, nw)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n, "the new checking balance and the closed card")
+
+	n, err = r.Record(ctx, nw)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "a closed account is only closed once")
+
+	snaps, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, snaps, 4)
+	assert.Equal(t, "closed", snaps[3].Status)
+
+	total, err := TotalSeries(snaps)
+	require.NoError(t, err)
+	require.Len(t, total, 2)
+	assert.Equal(t, int64(75000), total[0].Value.Amount())
+	assert.Equal(t, int64(120000), total[1].Value.Amount(), "the card's balance isn't carried past its closing")
+
+	credit, err := TypeSeries(snaps, "credit")
+	require.NoError(t, err)
+	require.Len(t, credit, 2)
+	assert.True(t, credit[1].Value.IsZero())
+}
+
+func TestRecorderNoBalanceAsOf(t *testing.T) {
+	ctx := context.Background()
+	store := NewJSONLStore(filepath.Join(t.TempDir(), "balances.jsonl"))
+	r := NewRecorder(store)
+
+	record := func(day int, balance int64) int {
+		r.Now = func() time.Time { return time.Date(2023, 1, day, 9, 0, 0, 0, time.UTC) }
+		nw := report(day, balance, 0)
+		nw.Accounts = nw.Accounts[:1]
+		nw.Accounts[0].BalanceAsOf = time.Time{}
+
+		n, err := r.Record(ctx, nw)
+		require.NoError(t, err)
+		return n
+	}
+	assert.Equal(t, 1, record(1, 100000))
+	assert.Equal(t, 0, record(2, 100000), "an unchanged balance isn't recorded again")
+	assert.Equal(t, 1, record(5, 90000))
+
+	snaps, err := store.Load(ctx)
+	require.NoError(t, err)
+
+	total, err := TotalSeries(snaps)
+	require.NoError(t, err)
+	require.Len(t, total, 2)
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), total[0].Date, "plotted when it was fetched")
+	assert.Equal(t, time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC), total[1].Date)
+	assert.Equal(t, int64(90000), total[1].Value.Amount())
+}
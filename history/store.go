@@ -0,0 +1,90 @@
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// JSONLStore stores snapshots in a JSON Lines file, one snapshot per line.
+// It is safe for concurrent use within a process.
+type JSONLStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewJSONLStore returns a store backed by the file at path. The file is
+// created on the first Append.
+func NewJSONLStore(path string) *JSONLStore {
+	return &JSONLStore{Path: path}
+}
+
+// Append adds snapshots to the end of the file.
+func (s *JSONLStore) Append(_ context.Context, snaps []Snapshot) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", s.Path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("close %s: %w", s.Path, cerr)
+		}
+	}()
+
+	enc := json.NewEncoder(f)
+	for _, snap := range snaps {
+		if err := enc.Encode(snap); err != nil {
+			return fmt.Errorf("encode snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Load reads every snapshot in the file. A missing file has no snapshots.
+func (s *JSONLStore) Load(_ context.Context) (ret []Snapshot, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", s.Path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("close %s: %w", s.Path, cerr)
+		}
+	}()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var snap Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snap); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", s.Path, line, err)
+		}
+		ret = append(ret, snap)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", s.Path, err)
+	}
+
+	return ret, nil
+}
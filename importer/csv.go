@@ -0,0 +1,305 @@
+package importer
+
+import (
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// CSVConfig describes the layout of a bank's CSV export. Column fields name a
+// header, or hold a zero based column index when NoHeader is set.
+type CSVConfig struct {
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune `json:"comma,omitempty"`
+	// NoHeader means the first row is data rather than column names.
+	NoHeader bool `json:"no_header,omitempty"`
+	// SkipRows is how many lines to drop before the header, for exports with
+	// a preamble.
+	SkipRows int `json:"skip_rows,omitempty"`
+
+	Date string `json:"date"`
+	// DateFormats are tried in order. Defaults to 2006-01-02, 01/02/2006 and
+	// 1/2/2006.
+	DateFormats []string `json:"date_formats,omitempty"`
+	Payee       string   `json:"payee"`
+	Notes       string   `json:"notes,omitempty"`
+	// Amount is a single signed amount column. Use Debit and Credit instead
+	// for exports that split them into two columns.
+	Amount string `json:"amount,omitempty"`
+	Debit  string `json:"debit,omitempty"`
+	Credit string `json:"credit,omitempty"`
+	// DebitsPositive means debits are positive in the Amount column. By
+	// default debits are expected to be negative, like most bank exports.
+	DebitsPositive bool `json:"debits_positive,omitempty"`
+	// DecimalComma means amounts are written like "1.234,56".
+	DecimalComma bool `json:"decimal_comma,omitempty"`
+	// Currency is a column holding the currency code. DefaultCurrency is
+	// used when it is unset or empty.
+	Currency        string `json:"currency,omitempty"`
+	DefaultCurrency string `json:"default_currency,omitempty"`
+	// ExternalID is a column holding a unique ID from the bank. When unset,
+	// one is derived from the row.
+	ExternalID string `json:"external_id,omitempty"`
+
+	AssetID *int64 `json:"asset_id,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+var thousands = regexp.MustCompile(`^[-+]?\d{1,3}(,\d{3})+(\.\d*)?$`)
+
+var defaultDateFormats = []string{"2006-01-02", "01/02/2006", "1/2/2006"}
+
+// ParseCSV reads a CSV export into transactions. Amounts follow Lunch Money's
+// default convention of positive debits, so insert them with
+// Importer.DebitAsNegative unset. Rows that are identical apart from their
+// position get distinct external IDs, so two equal purchases on one day are
+// both kept.
+func ParseCSV(r io.Reader, cfg *CSVConfig) ([]lunchmoney.InsertTransaction, error) {
+	if cfg.Date == "" || (cfg.Amount == "" && cfg.Debit == "" && cfg.Credit == "") {
+		return nil, fmt.Errorf("date and amount (or debit/credit) columns are required")
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	if cfg.Comma != 0 {
+		cr.Comma = cfg.Comma
+	}
+
+	for i := 0; i < cfg.SkipRows; i++ {
+		if _, err := cr.Read(); err != nil {
+			return nil, fmt.Errorf("skip row %d: %w", i+1, err)
+		}
+	}
+
+	var header []string
+	if !cfg.NoHeader {
+		h, err := cr.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read header: %w", err)
+		}
+		header = h
+	}
+
+	col := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+
+		if cfg.NoHeader {
+			i, err := strconv.Atoi(name)
+			if err != nil {
+				return -1, fmt.Errorf("column %q must be an index without a header: %w", name, err)
+			}
+			return i, nil
+		}
+
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), name) {
+				return i, nil
+			}
+		}
+
+		return -1, fmt.Errorf("column %q not in header %v", name, header)
+	}
+
+	cols := map[string]int{}
+	for field, name := range map[string]string{
+		"date":        cfg.Date,
+		"payee":       cfg.Payee,
+		"notes":       cfg.Notes,
+		"amount":      cfg.Amount,
+		"debit":       cfg.Debit,
+		"credit":      cfg.Credit,
+		"currency":    cfg.Currency,
+		"external_id": cfg.ExternalID,
+	} {
+		i, err := col(name)
+		if err != nil {
+			return nil, err
+		}
+		cols[field] = i
+	}
+
+	formats := cfg.DateFormats
+	if len(formats) == 0 {
+		formats = defaultDateFormats
+	}
+
+	var ret []lunchmoney.InsertTransaction
+	occurrences := map[string]int{}
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		get := func(field string) string {
+			i := cols[field]
+			if i < 0 || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		if strings.Join(row, "") == "" {
+			continue
+		}
+
+		date, err := parseDate(get("date"), formats)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		amount, err := rowAmount(cfg, get)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		currency := strings.ToLower(cmp.Or(get("currency"), cfg.DefaultCurrency))
+
+		t := lunchmoney.InsertTransaction{
+			Date:       date,
+			Amount:     amount,
+			Payee:      get("payee"),
+			Notes:      get("notes"),
+			Currency:   currency,
+			AssetID:    cfg.AssetID,
+			Status:     cfg.Status,
+			ExternalID: get("external_id"),
+		}
+
+		if t.ExternalID == "" {
+			asset := ""
+			if cfg.AssetID != nil {
+				asset = strconv.FormatInt(*cfg.AssetID, 10)
+			}
+			key := strings.Join([]string{asset, t.Date, t.Amount, t.Currency, t.Payee, t.Notes}, "\x1f")
+			occurrences[key]++
+			t.ExternalID = ExternalID(key, strconv.Itoa(occurrences[key]))
+		}
+
+		ret = append(ret, t)
+	}
+
+	return ret, nil
+}
+
+func parseDate(v string, formats []string) (string, error) {
+	for _, f := range formats {
+		if d, err := time.Parse(f, v); err == nil {
+			return d.Format("2006-01-02"), nil
+		}
+	}
+
+	return "", fmt.Errorf("date %q does not match any of %v", v, formats)
+}
+
+// rowAmount returns the row's amount with debits positive.
+func rowAmount(cfg *CSVConfig, get func(string) string) (string, error) {
+	if cfg.Amount != "" {
+		f, err := parseAmount(get("amount"), cfg.DecimalComma)
+		if err != nil {
+			return "", err
+		}
+
+		if !cfg.DebitsPositive {
+			f = -f
+		}
+
+		return formatAmount(f), nil
+	}
+
+	var debit, credit float64
+	var err error
+	if v := get("debit"); v != "" {
+		if debit, err = parseAmount(v, cfg.DecimalComma); err != nil {
+			return "", fmt.Errorf("debit: %w", err)
+		}
+	}
+
+	if v := get("credit"); v != "" {
+		if credit, err = parseAmount(v, cfg.DecimalComma); err != nil {
+			return "", fmt.Errorf("credit: %w", err)
+		}
+	}
+
+	// Some banks sign the debit column; either way it's money out.
+	if debit < 0 {
+		debit = -debit
+	}
+	if credit < 0 {
+		credit = -credit
+	}
+
+	return formatAmount(debit - credit), nil
+}
+
+// parseAmount accepts amounts like "1,234.56", "$12.00", "(4.50)" and
+// "4.50-". Thousands separators must group digits by three, so a decimal
+// comma isn't silently read as a thousands separator.
+func parseAmount(v string, decimalComma bool) (float64, error) {
+	s := strings.TrimSpace(v)
+	if decimalComma {
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+
+	if strings.HasSuffix(s, "-") {
+		negative = true
+		s = strings.TrimSuffix(s, "-")
+	}
+
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == ',', r == '-', r == '+':
+			return r
+		default:
+			return -1
+		}
+	}, s)
+
+	if strings.Contains(s, ",") && !thousands.MatchString(s) {
+		return 0, fmt.Errorf("%q has a misplaced thousands separator", v)
+	}
+	s = strings.ReplaceAll(s, ",", "")
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid amount: %w", v, err)
+	}
+
+	if negative {
+		f = -f
+	}
+
+	return f, nil
+}
+
+func formatAmount(f float64) string {
+	if f == 0 {
+		// Avoid "-0.0000".
+		f = math.Abs(f)
+	}
+
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const splitCSV = `Account export
+Posted Date,Description,Debit,Credit,Memo
+01/05/2023,SQ *BLUE BOTTLE,4.50,,
+01/05/2023,SQ *BLUE BOTTLE,4.50,,
+01/06/2023,PAYROLL,,"2,500.00",January
+01/07/2023,REFUND,(12.00),,
+`
+
+func TestParseCSV(t *testing.T) {
+	asset := int64(153)
+	got, err := ParseCSV(strings.NewReader(splitCSV), &CSVConfig{
+		SkipRows:        1,
+		Date:            "Posted Date",
+		Payee:           "Description",
+		Debit:           "Debit",
+		Credit:          "Credit",
+		Notes:           "Memo",
+		DefaultCurrency: "USD",
+		AssetID:         &asset,
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	assert.Equal(t, "2023-01-05", got[0].Date)
+	assert.Equal(t, "4.5000", got[0].Amount)
+	assert.Equal(t, "usd", got[0].Currency)
+	assert.Equal(t, &asset, got[0].AssetID)
+	assert.NotEqual(t, got[0].ExternalID, got[1].ExternalID, "identical rows get distinct ids")
+	assert.LessOrEqual(t, len(got[0].ExternalID), 75)
+	assert.Equal(t, "-2500.0000", got[2].Amount)
+	assert.Equal(t, "January", got[2].Notes)
+	assert.Equal(t, "12.0000", got[3].Amount)
+
+	again, err := ParseCSV(strings.NewReader(splitCSV), &CSVConfig{
+		SkipRows: 1, Date: "Posted Date", Payee: "Description", Debit: "Debit", Credit: "Credit", Notes: "Memo", DefaultCurrency: "USD", AssetID: &asset,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, got[0].ExternalID, again[0].ExternalID, "ids are stable across imports")
+}
+
+func TestParseCSVSignedAmount(t *testing.T) {
+	data := "2023-01-05;Coffee;-4,50;EUR;abc123\n2023-01-06;Refund;1.010,00;EUR;abc124\n"
+	_, err := ParseCSV(strings.NewReader(data), &CSVConfig{NoHeader: true, Comma: ';', Date: "0", Payee: "1", Amount: "2"})
+	require.Error(t, err, "decimal commas need DecimalComma")
+	assert.Contains(t, err.Error(), "misplaced thousands separator")
+
+	got, err := ParseCSV(strings.NewReader(data), &CSVConfig{NoHeader: true, Comma: ';', Date: "0", Payee: "1", Amount: "2", DecimalComma: true})
+	require.NoError(t, err)
+	assert.Equal(t, "4.5000", got[0].Amount)
+	assert.Equal(t, "-1010.0000", got[1].Amount)
+
+	data = "2023-01-05;Coffee;-4.50;EUR;abc123\n"
+	got, err = ParseCSV(strings.NewReader(data), &CSVConfig{NoHeader: true, Comma: ';', Date: "0", Payee: "1", Amount: "2", Currency: "3", ExternalID: "4"})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "4.5000", got[0].Amount)
+	assert.Equal(t, "eur", got[0].Currency)
+	assert.Equal(t, "abc123", got[0].ExternalID)
+
+	_, err = ParseCSV(strings.NewReader("Date,Amount\n"), &CSVConfig{Date: "Posted", Amount: "Amount"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `column "Posted" not in header`)
+}
+
+func TestImporterInsert(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req lunchmoney.InsertTransactionsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.False(t, req.SkipDuplicates, "off unless the Importer asks for it")
+		batches = append(batches, len(req.Transactions))
+
+		// Pretend the first transaction of each batch was already imported.
+		ids := []int64{}
+		for i := 1; i < len(req.Transactions); i++ {
+			ids = append(ids, int64(len(batches)*100+i))
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(lunchmoney.InsertTransactionsResponse{IDs: ids}))
+	}))
+	defer server.Close()
+
+	client, err := lunchmoney.NewClient("test-token")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+
+	txns := []lunchmoney.InsertTransaction{
+		{Date: "2023-01-01", Amount: "1", ExternalID: "a"},
+		{Date: "2023-01-01", Amount: "1", ExternalID: "a"},
+		{Date: "2023-01-02", Amount: "2", ExternalID: "b"},
+		{Date: "2023-01-03", Amount: "3", ExternalID: "c"},
+		{Date: "2023-01-04", Amount: "4", ExternalID: "d"},
+	}
+
+	im := &Importer{Client: client, BatchSize: 3}
+	res, err := im.Insert(context.Background(), txns)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 1}, batches)
+	assert.Equal(t, 5, res.Parsed)
+	assert.Equal(t, 2, res.Inserted)
+	assert.Equal(t, 3, res.Skipped)
+	assert.Len(t, res.IDs, 2)
+}
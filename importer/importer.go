@@ -0,0 +1,105 @@
+// Package importer turns bank exports into Lunch Money transactions and
+// inserts them in batches, using stable external IDs so re-importing the same
+// file doesn't create duplicates.
+package importer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/icco/lunchmoney"
+)
+
+// maxExternalID is the longest external ID the API accepts.
+const maxExternalID = 75
+
+// ExternalID derives a stable external ID from the given parts. It is a
+// prefixed SHA-256, well within the 75 character limit.
+func ExternalID(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return "lmimport-" + hex.EncodeToString(h[:])
+}
+
+// Importer inserts transactions through a Client.
+type Importer struct {
+	Client *lunchmoney.Client
+	// BatchSize is how many transactions are sent per request. Defaults to 100.
+	BatchSize int
+	// DebitAsNegative means negative amounts are debits. Importers set this
+	// from their own sign conventions.
+	DebitAsNegative   bool
+	ApplyRules        bool
+	CheckForRecurring bool
+	SkipBalanceUpdate bool
+	// SkipDuplicates also has Lunch Money drop transactions with the same
+	// date, payee and amount as an existing one. It is off by default, since
+	// it drops genuinely repeated charges; rows with an external ID already
+	// imported are skipped either way.
+	SkipDuplicates bool
+}
+
+// Result counts what happened during an import.
+type Result struct {
+	// Parsed is how many transactions were handed to Insert.
+	Parsed int
+	// Inserted is how many transactions Lunch Money created.
+	Inserted int
+	// Skipped is how many were dropped, either as duplicates within the input
+	// or because Lunch Money already had their external ID.
+	Skipped int
+	IDs     []int64
+}
+
+// Insert sends txns to Lunch Money in batches. Lunch Money skips rows whose
+// external ID was already imported, and transactions repeating an external
+// ID already seen in txns are skipped locally.
+func (im *Importer) Insert(ctx context.Context, txns []lunchmoney.InsertTransaction) (*Result, error) {
+	batchSize := im.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	res := &Result{Parsed: len(txns)}
+	seen := map[string]bool{}
+	var unique []lunchmoney.InsertTransaction
+	for _, t := range txns {
+		if len(t.ExternalID) > maxExternalID {
+			return res, fmt.Errorf("external id %q is longer than %d characters", t.ExternalID, maxExternalID)
+		}
+
+		if t.ExternalID != "" {
+			if seen[t.ExternalID] {
+				res.Skipped++
+				continue
+			}
+			seen[t.ExternalID] = true
+		}
+		unique = append(unique, t)
+	}
+
+	for start := 0; start < len(unique); start += batchSize {
+		end := min(start+batchSize, len(unique))
+		batch := unique[start:end]
+
+		resp, err := im.Client.TransactionService().Create(ctx, lunchmoney.InsertTransactionsRequest{
+			ApplyRules:        im.ApplyRules,
+			SkipDuplicates:    im.SkipDuplicates,
+			CheckForRecurring: im.CheckForRecurring,
+			DebitAsNegative:   im.DebitAsNegative,
+			SkipBalanceUpdate: im.SkipBalanceUpdate,
+			Transactions:      batch,
+		})
+		if err != nil {
+			return res, fmt.Errorf("insert batch %d-%d: %w", start, end, err)
+		}
+
+		res.IDs = append(res.IDs, resp.IDs...)
+		res.Inserted += len(resp.IDs)
+		res.Skipped += len(batch) - len(resp.IDs)
+	}
+
+	return res, nil
+}
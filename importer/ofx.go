@@ -0,0 +1,382 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// OFXTransaction is a single STMTTRN record.
+type OFXTransaction struct {
+	Type   string
+	Posted time.Time
+	// Amount is TRNAMT as written, negative for debits.
+	Amount string
+	FITID  string
+	Name   string
+	Memo   string
+}
+
+// OFXStatement is one bank or credit card statement from an OFX file.
+type OFXStatement struct {
+	AccountID    string
+	Currency     string
+	Transactions []OFXTransaction
+	// LedgerBalance is LEDGERBAL's BALAMT, empty if the statement had none.
+	LedgerBalance     string
+	LedgerBalanceAsOf time.Time
+}
+
+// InsertTransactions converts the statement's transactions for assetID.
+// FITID is used as the external ID, hashed if it is longer than the API
+// allows. Transactions without a FITID get one hashed from the account, date,
+// amount, name and memo, like CSV rows without one. Amounts are flipped to
+// Lunch Money's positive debit convention.
+func (s *OFXStatement) InsertTransactions(assetID int64) ([]lunchmoney.InsertTransaction, error) {
+	ret := make([]lunchmoney.InsertTransaction, 0, len(s.Transactions))
+	occurrences := map[string]int{}
+	for _, t := range s.Transactions {
+		f, err := strconv.ParseFloat(strings.ReplaceAll(t.Amount, ",", "."), 64)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %q is not a valid amount: %w", t.FITID, t.Amount, err)
+		}
+
+		date := t.Posted.Format("2006-01-02")
+		externalID := t.FITID
+		switch {
+		case externalID == "":
+			key := strings.Join([]string{s.AccountID, date, t.Amount, t.Name, t.Memo}, "\x1f")
+			occurrences[key]++
+			externalID = ExternalID(key, strconv.Itoa(occurrences[key]))
+		case len(externalID) > maxExternalID:
+			externalID = ExternalID(t.FITID)
+		}
+
+		payee := t.Name
+		notes := t.Memo
+		if payee == "" {
+			payee, notes = t.Memo, ""
+		}
+
+		ret = append(ret, lunchmoney.InsertTransaction{
+			Date:       date,
+			Amount:     formatAmount(-f),
+			Payee:      payee,
+			Notes:      notes,
+			Currency:   strings.ToLower(s.Currency),
+			AssetID:    &assetID,
+			ExternalID: externalID,
+		})
+	}
+
+	return ret, nil
+}
+
+// ParseOFX reads an OFX 1.x (SGML) or 2.x (XML) file and returns its bank
+// and credit card statements. QFX files are OFX with extra Intuit elements
+// and parse the same way.
+func ParseOFX(r io.Reader) ([]*OFXStatement, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read ofx: %w", err)
+	}
+
+	root, err := parseOFXTree(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*OFXStatement
+	for _, stmt := range root.findAll("STMTRS", "CCSTMTRS") {
+		s := &OFXStatement{
+			AccountID: stmt.find("ACCTID").text(),
+			Currency:  stmt.find("CURDEF").text(),
+		}
+
+		for _, trn := range stmt.findAll("STMTTRN") {
+			posted, err := parseOFXDate(trn.find("DTPOSTED").text())
+			if err != nil {
+				return nil, fmt.Errorf("transaction %s: %w", trn.find("FITID").text(), err)
+			}
+
+			s.Transactions = append(s.Transactions, OFXTransaction{
+				Type:   trn.find("TRNTYPE").text(),
+				Posted: posted,
+				Amount: trn.find("TRNAMT").text(),
+				FITID:  trn.find("FITID").text(),
+				Name:   trn.find("NAME").text(),
+				Memo:   trn.find("MEMO").text(),
+			})
+		}
+
+		if bal := stmt.find("LEDGERBAL"); bal != nil {
+			s.LedgerBalance = bal.find("BALAMT").text()
+			if asOf := bal.find("DTASOF").text(); asOf != "" {
+				if s.LedgerBalanceAsOf, err = parseOFXDate(asOf); err != nil {
+					return nil, fmt.Errorf("ledger balance: %w", err)
+				}
+			}
+		}
+
+		ret = append(ret, s)
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no statements found")
+	}
+
+	return ret, nil
+}
+
+// ImportOFX parses an OFX file with a single statement and inserts its
+// transactions into assetID. If updateBalance is set, the asset's balance is
+// set from the statement's ledger balance, negated for liabilities such as
+// credit cards, which OFX reports as negative. Files with statements for more
+// than one account are rejected; import those with ParseOFX and
+// OFXStatement.InsertTransactions, picking an asset for each account.
+func (im *Importer) ImportOFX(ctx context.Context, r io.Reader, assetID int64, updateBalance bool) (*Result, error) {
+	stmts, err := ParseOFX(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmts) > 1 {
+		accounts := make([]string, len(stmts))
+		for i, s := range stmts {
+			accounts[i] = s.AccountID
+		}
+		return nil, fmt.Errorf("ofx file has %d statements, for accounts %s: import them one at a time", len(stmts), strings.Join(accounts, ", "))
+	}
+
+	stmt := stmts[0]
+	txns, err := stmt.InsertTransactions(assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := im.Insert(ctx, txns)
+	if err != nil {
+		return res, err
+	}
+
+	if updateBalance && stmt.LedgerBalance != "" {
+		balance, err := im.ledgerBalance(ctx, assetID, stmt.LedgerBalance)
+		if err != nil {
+			return res, err
+		}
+
+		update := &lunchmoney.UpdateAsset{Balance: &balance}
+		if !stmt.LedgerBalanceAsOf.IsZero() {
+			asOf := stmt.LedgerBalanceAsOf.UTC().Format(time.RFC3339)
+			update.BalanceAsOf = &asOf
+		}
+		if stmt.Currency != "" {
+			currency := strings.ToLower(stmt.Currency)
+			update.Currency = &currency
+		}
+
+		if _, err := im.Client.AssetService().Update(ctx, assetID, update); err != nil {
+			return res, fmt.Errorf("update asset balance: %w", err)
+		}
+	}
+
+	return res, nil
+}
+
+// ledgerBalance converts an OFX ledger balance to Lunch Money's convention
+// for assetID, where a liability's balance is what is owed.
+func (im *Importer) ledgerBalance(ctx context.Context, assetID int64, balance string) (string, error) {
+	assets, err := im.Client.AssetService().List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get asset: %w", err)
+	}
+
+	idx := slices.IndexFunc(assets, func(a *lunchmoney.Asset) bool { return a.ID == assetID })
+	if idx < 0 {
+		return "", fmt.Errorf("asset %d not found", assetID)
+	}
+
+	if !lunchmoney.IsLiability(assets[idx].TypeName, assets[idx].SubtypeName) {
+		return balance, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.ReplaceAll(balance, ",", "."), 64)
+	if err != nil {
+		return "", fmt.Errorf("ledger balance %q is not a valid amount: %w", balance, err)
+	}
+
+	return formatAmount(-f), nil
+}
+
+// ofxNode is an element in an OFX document. SGML leaf elements have no end
+// tag, so a node holding text is closed as soon as the next tag opens.
+type ofxNode struct {
+	name     string
+	value    string
+	hasValue bool
+	children []*ofxNode
+}
+
+func (n *ofxNode) text() string {
+	if n == nil {
+		return ""
+	}
+
+	return n.value
+}
+
+// find returns the first descendant with the given name, depth first.
+func (n *ofxNode) find(name string) *ofxNode {
+	if n == nil {
+		return nil
+	}
+
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+
+		if f := c.find(name); f != nil {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// findAll returns every descendant with one of the given names, without
+// looking inside matches.
+func (n *ofxNode) findAll(names ...string) []*ofxNode {
+	var ret []*ofxNode
+	for _, c := range n.children {
+		matched := false
+		for _, name := range names {
+			if c.name == name {
+				matched = true
+				break
+			}
+		}
+
+		if matched {
+			ret = append(ret, c)
+			continue
+		}
+
+		ret = append(ret, c.findAll(names...)...)
+	}
+
+	return ret
+}
+
+func parseOFXTree(doc string) (*ofxNode, error) {
+	start := strings.Index(strings.ToUpper(doc), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("no <OFX> element found")
+	}
+	doc = doc[start:]
+
+	root := &ofxNode{}
+	stack := []*ofxNode{root}
+	top := func() *ofxNode { return stack[len(stack)-1] }
+
+	for len(doc) > 0 {
+		lt := strings.IndexByte(doc, '<')
+		if lt < 0 {
+			lt = len(doc)
+		}
+
+		if text := strings.TrimSpace(doc[:lt]); text != "" {
+			n := top()
+			n.value = html.UnescapeString(text)
+			n.hasValue = true
+		}
+
+		if lt == len(doc) {
+			break
+		}
+		doc = doc[lt:]
+
+		gt := strings.IndexByte(doc, '>')
+		if gt < 0 {
+			return nil, fmt.Errorf("unterminated tag %q", doc)
+		}
+		tag := strings.ToUpper(strings.TrimSpace(doc[1:gt]))
+		doc = doc[gt+1:]
+
+		switch {
+		case strings.HasPrefix(tag, "?"), strings.HasPrefix(tag, "!"):
+			continue
+		case strings.HasPrefix(tag, "/"):
+			name := strings.TrimSpace(tag[1:])
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		default:
+			selfClosing := strings.HasSuffix(tag, "/")
+			if selfClosing {
+				tag = strings.TrimSpace(strings.TrimSuffix(tag, "/"))
+			}
+
+			if n := top(); n.hasValue && len(n.children) == 0 && len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+
+			n := &ofxNode{name: tag}
+			parent := top()
+			parent.children = append(parent.children, n)
+			if !selfClosing {
+				stack = append(stack, n)
+			}
+		}
+	}
+
+	return root, nil
+}
+
+// parseOFXDate parses dates like 20230105, 20230105120000 and
+// 20230105120000.000[-5:EST].
+func parseOFXDate(v string) (time.Time, error) {
+	s := strings.TrimSpace(v)
+	loc := time.UTC
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		tz := strings.TrimSuffix(s[i+1:], "]")
+		s = s[:i]
+		offset := tz
+		if j := strings.IndexByte(tz, ':'); j >= 0 {
+			offset = tz[:j]
+		}
+
+		hours, err := strconv.ParseFloat(offset, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("date %q has bad timezone: %w", v, err)
+		}
+		loc = time.FixedZone(tz, int(hours*3600))
+	}
+
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+
+	layouts := map[int]string{8: "20060102", 12: "200601021504", 14: "20060102150405"}
+	layout, ok := layouts[len(s)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("date %q is not an OFX date", v)
+	}
+
+	t, err := time.ParseInLocation(layout, s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q: %w", v, err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,209 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sgmlOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20230110120000</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>1
+<STMTRS>
+<CURDEF>USD
+<BANKACCTFROM>
+<BANKID>123456789
+<ACCTID>000111222
+<ACCTTYPE>CHECKING
+</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20230101
+<DTEND>20230110
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20230105120000.000[-5:EST]
+<TRNAMT>-4.50
+<FITID>2023010501
+<NAME>SQ *BLUE BOTTLE
+<MEMO>
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20230106
+<TRNAMT>2500.00
+<FITID>2023010602
+<NAME>PAYROLL
+<MEMO>JANUARY &amp; BONUS
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>1234.56
+<DTASOF>20230110
+</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlOFX = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <TRNUID>1</TRNUID>
+      <CCSTMTRS>
+        <CURDEF>CAD</CURDEF>
+        <CCACCTFROM><ACCTID>4111</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20230201</DTPOSTED>
+            <TRNAMT>-12.00</TRNAMT>
+            <FITID>abc</FITID>
+            <NAME>Netflix</NAME>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL><BALAMT>-12.00</BALAMT><DTASOF>20230201</DTASOF></LEDGERBAL>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestParseOFX(t *testing.T) {
+	stmts, err := ParseOFX(strings.NewReader(sgmlOFX))
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+
+	s := stmts[0]
+	assert.Equal(t, "000111222", s.AccountID)
+	assert.Equal(t, "USD", s.Currency)
+	assert.Equal(t, "1234.56", s.LedgerBalance)
+	require.Len(t, s.Transactions, 2)
+	assert.Equal(t, "SQ *BLUE BOTTLE", s.Transactions[0].Name)
+	assert.Equal(t, "", s.Transactions[0].Memo)
+	assert.Equal(t, "JANUARY & BONUS", s.Transactions[1].Memo)
+	assert.Equal(t, time.Date(2023, 1, 5, 17, 0, 0, 0, time.UTC), s.Transactions[0].Posted.UTC())
+
+	txns, err := s.InsertTransactions(153)
+	require.NoError(t, err)
+	assert.Equal(t, "2023-01-05", txns[0].Date)
+	assert.Equal(t, "4.5000", txns[0].Amount)
+	assert.Equal(t, "2023010501", txns[0].ExternalID)
+	assert.Equal(t, "usd", txns[0].Currency)
+	assert.Equal(t, "-2500.0000", txns[1].Amount)
+
+	stmts, err = ParseOFX(strings.NewReader(xmlOFX))
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+	assert.Equal(t, "4111", stmts[0].AccountID)
+	assert.Equal(t, "CAD", stmts[0].Currency)
+	require.Len(t, stmts[0].Transactions, 1)
+	assert.Equal(t, "Netflix", stmts[0].Transactions[0].Name)
+	assert.Equal(t, "-12.00", stmts[0].LedgerBalance)
+
+	_, err = ParseOFX(strings.NewReader("not ofx"))
+	require.Error(t, err)
+}
+
+func TestImportOFX(t *testing.T) {
+	var asset lunchmoney.UpdateAsset
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/v1/transactions":
+			_, err := w.Write([]byte(`{"ids": [1, 2]}`))
+			require.NoError(t, err)
+		case "/v1/assets":
+			_, err := w.Write([]byte(`{"assets": [
+				{"id": 153, "type_name": "cash", "balance": "0", "currency": "usd"},
+				{"id": 154, "type_name": "credit", "balance": "0", "currency": "cad"}
+			]}`))
+			require.NoError(t, err)
+		case "/v1/assets/153", "/v1/assets/154":
+			assert.Equal(t, http.MethodPut, r.Method)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&asset))
+			_, err := w.Write([]byte(`{"id": 153}`))
+			require.NoError(t, err)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := lunchmoney.NewClient("test-token")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+
+	im := &Importer{Client: client}
+	res, err := im.ImportOFX(context.Background(), strings.NewReader(sgmlOFX), 153, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Inserted)
+	require.NotNil(t, asset.Balance)
+	assert.Equal(t, "1234.56", *asset.Balance)
+	assert.Equal(t, "2023-01-10T00:00:00Z", *asset.BalanceAsOf)
+	assert.Equal(t, "usd", *asset.Currency)
+
+	// A credit card's negative ledger balance is what is owed.
+	asset = lunchmoney.UpdateAsset{}
+	_, err = im.ImportOFX(context.Background(), strings.NewReader(xmlOFX), 154, true)
+	require.NoError(t, err)
+	require.NotNil(t, asset.Balance)
+	assert.Equal(t, "12.0000", *asset.Balance)
+
+	// Statements for two accounts can't both go into one asset.
+	requests = 0
+	twoAccounts := strings.Replace(xmlOFX, "</CREDITCARDMSGSRSV1>", `</CREDITCARDMSGSRSV1>
+  <BANKMSGSRSV1><STMTTRNRS><STMTRS>
+    <CURDEF>CAD</CURDEF>
+    <BANKACCTFROM><ACCTID>5222</ACCTID></BANKACCTFROM>
+    <LEDGERBAL><BALAMT>80.00</BALAMT></LEDGERBAL>
+  </STMTRS></STMTTRNRS></BANKMSGSRSV1>`, 1)
+	_, err = im.ImportOFX(context.Background(), strings.NewReader(twoAccounts), 153, true)
+	assert.ErrorContains(t, err, "2 statements, for accounts 4111, 5222")
+	assert.Zero(t, requests)
+}
+
+func TestOFXInsertTransactionsWithoutFITID(t *testing.T) {
+	posted := time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC)
+	stmt := &OFXStatement{
+		AccountID: "000111222",
+		Currency:  "USD",
+		Transactions: []OFXTransaction{
+			{Posted: posted, Amount: "-4.50", Name: "BLUE BOTTLE"},
+			{Posted: posted, Amount: "-4.50", Name: "BLUE BOTTLE"},
+			{Posted: posted, Amount: "-4.50", FITID: "abc", Name: "BLUE BOTTLE"},
+		},
+	}
+
+	txns, err := stmt.InsertTransactions(153)
+	require.NoError(t, err)
+	require.Len(t, txns, 3)
+	assert.NotEmpty(t, txns[0].ExternalID)
+	assert.NotEqual(t, txns[0].ExternalID, txns[1].ExternalID, "repeated charges on one day stay distinct")
+	assert.Equal(t, "abc", txns[2].ExternalID)
+
+	again, err := stmt.InsertTransactions(153)
+	require.NoError(t, err)
+	assert.Equal(t, txns[0].ExternalID, again[0].ExternalID, "re-importing the file gives the same IDs")
+}
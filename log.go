@@ -0,0 +1,114 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	bearerRE = regexp.MustCompile(`(?i)bearer\s+[^\s"]+`)
+	emailRE  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	maskRE   = regexp.MustCompile(`("\w*mask"\s*:\s*)"[^"]*"`)
+)
+
+// RedactBody is the default Client.Redact. It replaces bearer tokens, email
+// addresses and account masks with REDACTED.
+func RedactBody(body string) string {
+	body = bearerRE.ReplaceAllString(body, "Bearer REDACTED")
+	body = emailRE.ReplaceAllString(body, "REDACTED")
+	return maskRE.ReplaceAllString(body, `${1}"REDACTED"`)
+}
+
+// logged performs req, logging it to c.Logger once the response has been
+// read. Each request is logged once, with a retries attribute counting how
+// many more times than once the transport wrote it, for example when a
+// retrying transport resent it after a 429.
+func (c *Client) logged(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	var writes atomic.Int64
+	trace := &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) { writes.Add(1) },
+	}
+	retries := func() slog.Attr {
+		return slog.Int64("retries", max(0, writes.Load()-1))
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+	}
+	if req.URL.RawQuery != "" {
+		attrs = append(attrs, slog.String("query", req.URL.RawQuery))
+	}
+
+	resp, err := c.HTTP.Do(req.WithContext(httptrace.WithClientTrace(ctx, trace)))
+	if err != nil {
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)), retries(), slog.Any("error", err))
+		c.Logger.LogAttrs(ctx, slog.LevelError, "lunchmoney request", attrs...)
+		return nil, err
+	}
+
+	// Read the body now so its size and the full duration can be logged.
+	// Callers read all of it anyway.
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	attrs = append(attrs,
+		slog.Int("status", resp.StatusCode),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("size", len(body)),
+		retries(),
+	)
+	level := slog.LevelInfo
+	switch {
+	case err != nil:
+		level = slog.LevelError
+		attrs = append(attrs, slog.Any("error", err))
+	case resp.StatusCode >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+	c.Logger.LogAttrs(ctx, level, "lunchmoney request", attrs...)
+
+	if c.Logger.Enabled(ctx, slog.LevelDebug) {
+		c.logBodies(ctx, req, body)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) logBodies(ctx context.Context, req *http.Request, respBody []byte) {
+	redact := c.Redact
+	if redact == nil {
+		redact = RedactBody
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+	}
+
+	if req.GetBody != nil {
+		if r, err := req.GetBody(); err == nil {
+			if b, err := io.ReadAll(r); err == nil && len(b) > 0 {
+				attrs = append(attrs, slog.String("request_body", redact(string(b))))
+			}
+		}
+	}
+	attrs = append(attrs, slog.String("response_body", redact(string(respBody))))
+
+	c.Logger.LogAttrs(ctx, slog.LevelDebug, "lunchmoney bodies", attrs...)
+}
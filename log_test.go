@@ -0,0 +1,150 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "email", in: `{"user_email": "user-1@lunchmoney.dev"}`, want: `{"user_email": "REDACTED"}`},
+		{name: "bearer", in: `Authorization: Bearer abc123`, want: `Authorization: Bearer REDACTED`},
+		{name: "mask", in: `{"mask": "1234", "plaid_account_mask":"9876"}`, want: `{"mask": "REDACTED", "plaid_account_mask":"REDACTED"}`},
+		{name: "untouched", in: `{"payee": "Blue Bottle"}`, want: `{"payee": "Blue Bottle"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RedactBody(tt.in))
+		})
+	}
+}
+
+func TestLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/categories/1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "Category ID not found"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"plaid_accounts": [{"id": 1, "name": "Card", "mask": "1234", "balance": "1.00", "currency": "usd"}]}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		level  slog.Level
+		bodies bool
+	}{
+		{name: "info", level: slog.LevelInfo},
+		{name: "debug", level: slog.LevelDebug, bodies: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client, err := NewClient("secret-token")
+			require.NoError(t, err)
+			client.Base, err = url.Parse(server.URL)
+			require.NoError(t, err)
+			client.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: tt.level}))
+
+			accounts, err := client.Plaid.List(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "1234", accounts[0].Mask, "logging doesn't change the response")
+
+			_, err = client.Categories.Get(context.Background(), 1)
+			require.Error(t, err)
+
+			var records []map[string]any
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var r map[string]any
+				require.NoError(t, json.Unmarshal([]byte(line), &r))
+				records = append(records, r)
+			}
+
+			var requests []map[string]any
+			for _, r := range records {
+				if r["msg"] == "lunchmoney request" {
+					requests = append(requests, r)
+				}
+			}
+			require.Len(t, requests, 2)
+			assert.Equal(t, "INFO", requests[0]["level"])
+			assert.Equal(t, "GET", requests[0]["method"])
+			assert.Equal(t, "/v1/plaid_accounts", requests[0]["path"])
+			assert.Equal(t, 200.0, requests[0]["status"])
+			assert.NotZero(t, requests[0]["size"])
+			assert.Contains(t, requests[0], "duration")
+			assert.Equal(t, 0.0, requests[0]["retries"])
+			assert.Equal(t, "WARN", requests[1]["level"])
+			assert.Equal(t, 404.0, requests[1]["status"])
+
+			assert.Equal(t, tt.bodies, strings.Contains(buf.String(), "lunchmoney bodies"))
+			assert.NotContains(t, buf.String(), "1234")
+			assert.NotContains(t, buf.String(), "secret-token")
+		})
+	}
+}
+
+// retryTransport resends a request once if the first response is a 429.
+type retryTransport struct {
+	next http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+
+	return t.next.RoundTrip(req)
+}
+
+func TestLoggerRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": "Too many requests"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := NewClient("secret-token")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+	client.HTTP.Transport = &retryTransport{next: client.HTTP.Transport}
+	client.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+
+	_, err = client.Tags.List(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, 200.0, record["status"])
+	assert.Equal(t, 1.0, record["retries"])
+}
@@ -0,0 +1,7 @@
+// Package lunchmoneymock provides mocks of the lunchmoney service
+// interfaces and of lunchmoney.API, generated with moq. Set the Func field
+// for each method the code under test calls; calls are recorded and can be
+// inspected afterwards.
+//
+// Regenerate with go generate in the lunchmoney package.
+package lunchmoneymock
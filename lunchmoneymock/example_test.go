@@ -0,0 +1,42 @@
+package lunchmoneymock_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/lunchmoneymock"
+)
+
+// uncategorized is business logic that only needs to read transactions.
+func uncategorized(ctx context.Context, txns lunchmoney.TransactionService) (int, error) {
+	all, err := txns.List(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, t := range all {
+		if t.CategoryID == 0 {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func Example() {
+	mock := &lunchmoneymock.TransactionServiceMock{
+		ListFunc: func(context.Context, *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+			return []*lunchmoney.Transaction{{ID: 1, CategoryID: 10}, {ID: 2}}, nil
+		},
+	}
+
+	n, err := uncategorized(context.Background(), mock)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(n, len(mock.ListCalls()))
+	// Output: 1 1
+}
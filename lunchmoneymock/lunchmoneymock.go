@@ -0,0 +1,1830 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package lunchmoneymock
+
+import (
+	"context"
+	"github.com/icco/lunchmoney"
+	"sync"
+)
+
+// Ensure, that APIMock does implement lunchmoney.API.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.API = &APIMock{}
+
+// APIMock is a mock implementation of lunchmoney.API.
+//
+//	func TestSomethingThatUsesAPI(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.API
+//		mockedAPI := &APIMock{
+//			GetAssetsFunc: func(ctx context.Context) ([]*lunchmoney.Asset, error) {
+//				panic("mock out the GetAssets method")
+//			},
+//			GetBudgetsFunc: func(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+//				panic("mock out the GetBudgets method")
+//			},
+//			GetCategoriesFunc: func(ctx context.Context) ([]*lunchmoney.Category, error) {
+//				panic("mock out the GetCategories method")
+//			},
+//			GetCategoryFunc: func(ctx context.Context, id int64) (*lunchmoney.Category, error) {
+//				panic("mock out the GetCategory method")
+//			},
+//			GetCryptoFunc: func(ctx context.Context) ([]*lunchmoney.Crypto, error) {
+//				panic("mock out the GetCrypto method")
+//			},
+//			GetPlaidAccountsFunc: func(ctx context.Context) ([]*lunchmoney.PlaidAccount, error) {
+//				panic("mock out the GetPlaidAccounts method")
+//			},
+//			GetRecurringExpensesFunc: func(ctx context.Context, filters *lunchmoney.RecurringExpenseFilters) ([]*lunchmoney.RecurringExpense, error) {
+//				panic("mock out the GetRecurringExpenses method")
+//			},
+//			GetTagsFunc: func(ctx context.Context) ([]*lunchmoney.Tag, error) {
+//				panic("mock out the GetTags method")
+//			},
+//			GetTransactionFunc: func(ctx context.Context, id int64, filters *lunchmoney.TransactionFilters) (*lunchmoney.Transaction, error) {
+//				panic("mock out the GetTransaction method")
+//			},
+//			GetTransactionsFunc: func(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+//				panic("mock out the GetTransactions method")
+//			},
+//			GetUserFunc: func(ctx context.Context) (*lunchmoney.User, error) {
+//				panic("mock out the GetUser method")
+//			},
+//			InsertTransactionsFunc: func(ctx context.Context, itReq lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error) {
+//				panic("mock out the InsertTransactions method")
+//			},
+//			UpdateAssetFunc: func(ctx context.Context, id int64, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error) {
+//				panic("mock out the UpdateAsset method")
+//			},
+//			UpdateTransactionFunc: func(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+//				panic("mock out the UpdateTransaction method")
+//			},
+//			UpdateTransactionWithOptionsFunc: func(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction, opts *lunchmoney.UpdateTransactionOptions) (*lunchmoney.UpdateTransactionResp, error) {
+//				panic("mock out the UpdateTransactionWithOptions method")
+//			},
+//			UpdateTransactionsFunc: func(ctx context.Context, updates map[int64]*lunchmoney.UpdateTransaction, opts *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error) {
+//				panic("mock out the UpdateTransactions method")
+//			},
+//		}
+//
+//		// use mockedAPI in code that requires lunchmoney.API
+//		// and then make assertions.
+//
+//	}
+type APIMock struct {
+	// GetAssetsFunc mocks the GetAssets method.
+	GetAssetsFunc func(ctx context.Context) ([]*lunchmoney.Asset, error)
+
+	// GetBudgetsFunc mocks the GetBudgets method.
+	GetBudgetsFunc func(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error)
+
+	// GetCategoriesFunc mocks the GetCategories method.
+	GetCategoriesFunc func(ctx context.Context) ([]*lunchmoney.Category, error)
+
+	// GetCategoryFunc mocks the GetCategory method.
+	GetCategoryFunc func(ctx context.Context, id int64) (*lunchmoney.Category, error)
+
+	// GetCryptoFunc mocks the GetCrypto method.
+	GetCryptoFunc func(ctx context.Context) ([]*lunchmoney.Crypto, error)
+
+	// GetPlaidAccountsFunc mocks the GetPlaidAccounts method.
+	GetPlaidAccountsFunc func(ctx context.Context) ([]*lunchmoney.PlaidAccount, error)
+
+	// GetRecurringExpensesFunc mocks the GetRecurringExpenses method.
+	GetRecurringExpensesFunc func(ctx context.Context, filters *lunchmoney.RecurringExpenseFilters) ([]*lunchmoney.RecurringExpense, error)
+
+	// GetTagsFunc mocks the GetTags method.
+	GetTagsFunc func(ctx context.Context) ([]*lunchmoney.Tag, error)
+
+	// GetTransactionFunc mocks the GetTransaction method.
+	GetTransactionFunc func(ctx context.Context, id int64, filters *lunchmoney.TransactionFilters) (*lunchmoney.Transaction, error)
+
+	// GetTransactionsFunc mocks the GetTransactions method.
+	GetTransactionsFunc func(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error)
+
+	// GetUserFunc mocks the GetUser method.
+	GetUserFunc func(ctx context.Context) (*lunchmoney.User, error)
+
+	// InsertTransactionsFunc mocks the InsertTransactions method.
+	InsertTransactionsFunc func(ctx context.Context, itReq lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error)
+
+	// UpdateAssetFunc mocks the UpdateAsset method.
+	UpdateAssetFunc func(ctx context.Context, id int64, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error)
+
+	// UpdateTransactionFunc mocks the UpdateTransaction method.
+	UpdateTransactionFunc func(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error)
+
+	// UpdateTransactionWithOptionsFunc mocks the UpdateTransactionWithOptions method.
+	UpdateTransactionWithOptionsFunc func(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction, opts *lunchmoney.UpdateTransactionOptions) (*lunchmoney.UpdateTransactionResp, error)
+
+	// UpdateTransactionsFunc mocks the UpdateTransactions method.
+	UpdateTransactionsFunc func(ctx context.Context, updates map[int64]*lunchmoney.UpdateTransaction, opts *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAssets holds details about calls to the GetAssets method.
+		GetAssets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetBudgets holds details about calls to the GetBudgets method.
+		GetBudgets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filters is the filters argument value.
+			Filters *lunchmoney.BudgetFilters
+		}
+		// GetCategories holds details about calls to the GetCategories method.
+		GetCategories []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetCategory holds details about calls to the GetCategory method.
+		GetCategory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetCrypto holds details about calls to the GetCrypto method.
+		GetCrypto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetPlaidAccounts holds details about calls to the GetPlaidAccounts method.
+		GetPlaidAccounts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetRecurringExpenses holds details about calls to the GetRecurringExpenses method.
+		GetRecurringExpenses []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filters is the filters argument value.
+			Filters *lunchmoney.RecurringExpenseFilters
+		}
+		// GetTags holds details about calls to the GetTags method.
+		GetTags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetTransaction holds details about calls to the GetTransaction method.
+		GetTransaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Filters is the filters argument value.
+			Filters *lunchmoney.TransactionFilters
+		}
+		// GetTransactions holds details about calls to the GetTransactions method.
+		GetTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filters is the filters argument value.
+			Filters *lunchmoney.TransactionFilters
+		}
+		// GetUser holds details about calls to the GetUser method.
+		GetUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// InsertTransactions holds details about calls to the InsertTransactions method.
+		InsertTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ItReq is the itReq argument value.
+			ItReq lunchmoney.InsertTransactionsRequest
+		}
+		// UpdateAsset holds details about calls to the UpdateAsset method.
+		UpdateAsset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Asset is the asset argument value.
+			Asset *lunchmoney.UpdateAsset
+		}
+		// UpdateTransaction holds details about calls to the UpdateTransaction method.
+		UpdateTransaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Ut is the ut argument value.
+			Ut *lunchmoney.UpdateTransaction
+		}
+		// UpdateTransactionWithOptions holds details about calls to the UpdateTransactionWithOptions method.
+		UpdateTransactionWithOptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Ut is the ut argument value.
+			Ut *lunchmoney.UpdateTransaction
+			// Opts is the opts argument value.
+			Opts *lunchmoney.UpdateTransactionOptions
+		}
+		// UpdateTransactions holds details about calls to the UpdateTransactions method.
+		UpdateTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Updates is the updates argument value.
+			Updates map[int64]*lunchmoney.UpdateTransaction
+			// Opts is the opts argument value.
+			Opts *lunchmoney.BulkUpdateOptions
+		}
+	}
+	lockGetAssets                    sync.RWMutex
+	lockGetBudgets                   sync.RWMutex
+	lockGetCategories                sync.RWMutex
+	lockGetCategory                  sync.RWMutex
+	lockGetCrypto                    sync.RWMutex
+	lockGetPlaidAccounts             sync.RWMutex
+	lockGetRecurringExpenses         sync.RWMutex
+	lockGetTags                      sync.RWMutex
+	lockGetTransaction               sync.RWMutex
+	lockGetTransactions              sync.RWMutex
+	lockGetUser                      sync.RWMutex
+	lockInsertTransactions           sync.RWMutex
+	lockUpdateAsset                  sync.RWMutex
+	lockUpdateTransaction            sync.RWMutex
+	lockUpdateTransactionWithOptions sync.RWMutex
+	lockUpdateTransactions           sync.RWMutex
+}
+
+// GetAssets calls GetAssetsFunc.
+func (mock *APIMock) GetAssets(ctx context.Context) ([]*lunchmoney.Asset, error) {
+	if mock.GetAssetsFunc == nil {
+		panic("APIMock.GetAssetsFunc: method is nil but API.GetAssets was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAssets.Lock()
+	mock.calls.GetAssets = append(mock.calls.GetAssets, callInfo)
+	mock.lockGetAssets.Unlock()
+	return mock.GetAssetsFunc(ctx)
+}
+
+// GetAssetsCalls gets all the calls that were made to GetAssets.
+// Check the length with:
+//
+//	len(mockedAPI.GetAssetsCalls())
+func (mock *APIMock) GetAssetsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAssets.RLock()
+	calls = mock.calls.GetAssets
+	mock.lockGetAssets.RUnlock()
+	return calls
+}
+
+// GetBudgets calls GetBudgetsFunc.
+func (mock *APIMock) GetBudgets(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	if mock.GetBudgetsFunc == nil {
+		panic("APIMock.GetBudgetsFunc: method is nil but API.GetBudgets was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Filters *lunchmoney.BudgetFilters
+	}{
+		Ctx:     ctx,
+		Filters: filters,
+	}
+	mock.lockGetBudgets.Lock()
+	mock.calls.GetBudgets = append(mock.calls.GetBudgets, callInfo)
+	mock.lockGetBudgets.Unlock()
+	return mock.GetBudgetsFunc(ctx, filters)
+}
+
+// GetBudgetsCalls gets all the calls that were made to GetBudgets.
+// Check the length with:
+//
+//	len(mockedAPI.GetBudgetsCalls())
+func (mock *APIMock) GetBudgetsCalls() []struct {
+	Ctx     context.Context
+	Filters *lunchmoney.BudgetFilters
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Filters *lunchmoney.BudgetFilters
+	}
+	mock.lockGetBudgets.RLock()
+	calls = mock.calls.GetBudgets
+	mock.lockGetBudgets.RUnlock()
+	return calls
+}
+
+// GetCategories calls GetCategoriesFunc.
+func (mock *APIMock) GetCategories(ctx context.Context) ([]*lunchmoney.Category, error) {
+	if mock.GetCategoriesFunc == nil {
+		panic("APIMock.GetCategoriesFunc: method is nil but API.GetCategories was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetCategories.Lock()
+	mock.calls.GetCategories = append(mock.calls.GetCategories, callInfo)
+	mock.lockGetCategories.Unlock()
+	return mock.GetCategoriesFunc(ctx)
+}
+
+// GetCategoriesCalls gets all the calls that were made to GetCategories.
+// Check the length with:
+//
+//	len(mockedAPI.GetCategoriesCalls())
+func (mock *APIMock) GetCategoriesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetCategories.RLock()
+	calls = mock.calls.GetCategories
+	mock.lockGetCategories.RUnlock()
+	return calls
+}
+
+// GetCategory calls GetCategoryFunc.
+func (mock *APIMock) GetCategory(ctx context.Context, id int64) (*lunchmoney.Category, error) {
+	if mock.GetCategoryFunc == nil {
+		panic("APIMock.GetCategoryFunc: method is nil but API.GetCategory was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetCategory.Lock()
+	mock.calls.GetCategory = append(mock.calls.GetCategory, callInfo)
+	mock.lockGetCategory.Unlock()
+	return mock.GetCategoryFunc(ctx, id)
+}
+
+// GetCategoryCalls gets all the calls that were made to GetCategory.
+// Check the length with:
+//
+//	len(mockedAPI.GetCategoryCalls())
+func (mock *APIMock) GetCategoryCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	mock.lockGetCategory.RLock()
+	calls = mock.calls.GetCategory
+	mock.lockGetCategory.RUnlock()
+	return calls
+}
+
+// GetCrypto calls GetCryptoFunc.
+func (mock *APIMock) GetCrypto(ctx context.Context) ([]*lunchmoney.Crypto, error) {
+	if mock.GetCryptoFunc == nil {
+		panic("APIMock.GetCryptoFunc: method is nil but API.GetCrypto was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetCrypto.Lock()
+	mock.calls.GetCrypto = append(mock.calls.GetCrypto, callInfo)
+	mock.lockGetCrypto.Unlock()
+	return mock.GetCryptoFunc(ctx)
+}
+
+// GetCryptoCalls gets all the calls that were made to GetCrypto.
+// Check the length with:
+//
+//	len(mockedAPI.GetCryptoCalls())
+func (mock *APIMock) GetCryptoCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetCrypto.RLock()
+	calls = mock.calls.GetCrypto
+	mock.lockGetCrypto.RUnlock()
+	return calls
+}
+
+// GetPlaidAccounts calls GetPlaidAccountsFunc.
+func (mock *APIMock) GetPlaidAccounts(ctx context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	if mock.GetPlaidAccountsFunc == nil {
+		panic("APIMock.GetPlaidAccountsFunc: method is nil but API.GetPlaidAccounts was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetPlaidAccounts.Lock()
+	mock.calls.GetPlaidAccounts = append(mock.calls.GetPlaidAccounts, callInfo)
+	mock.lockGetPlaidAccounts.Unlock()
+	return mock.GetPlaidAccountsFunc(ctx)
+}
+
+// GetPlaidAccountsCalls gets all the calls that were made to GetPlaidAccounts.
+// Check the length with:
+//
+//	len(mockedAPI.GetPlaidAccountsCalls())
+func (mock *APIMock) GetPlaidAccountsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetPlaidAccounts.RLock()
+	calls = mock.calls.GetPlaidAccounts
+	mock.lockGetPlaidAccounts.RUnlock()
+	return calls
+}
+
+// GetRecurringExpenses calls GetRecurringExpensesFunc.
+func (mock *APIMock) GetRecurringExpenses(ctx context.Context, filters *lunchmoney.RecurringExpenseFilters) ([]*lunchmoney.RecurringExpense, error) {
+	if mock.GetRecurringExpensesFunc == nil {
+		panic("APIMock.GetRecurringExpensesFunc: method is nil but API.GetRecurringExpenses was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Filters *lunchmoney.RecurringExpenseFilters
+	}{
+		Ctx:     ctx,
+		Filters: filters,
+	}
+	mock.lockGetRecurringExpenses.Lock()
+	mock.calls.GetRecurringExpenses = append(mock.calls.GetRecurringExpenses, callInfo)
+	mock.lockGetRecurringExpenses.Unlock()
+	return mock.GetRecurringExpensesFunc(ctx, filters)
+}
+
+// GetRecurringExpensesCalls gets all the calls that were made to GetRecurringExpenses.
+// Check the length with:
+//
+//	len(mockedAPI.GetRecurringExpensesCalls())
+func (mock *APIMock) GetRecurringExpensesCalls() []struct {
+	Ctx     context.Context
+	Filters *lunchmoney.RecurringExpenseFilters
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Filters *lunchmoney.RecurringExpenseFilters
+	}
+	mock.lockGetRecurringExpenses.RLock()
+	calls = mock.calls.GetRecurringExpenses
+	mock.lockGetRecurringExpenses.RUnlock()
+	return calls
+}
+
+// GetTags calls GetTagsFunc.
+func (mock *APIMock) GetTags(ctx context.Context) ([]*lunchmoney.Tag, error) {
+	if mock.GetTagsFunc == nil {
+		panic("APIMock.GetTagsFunc: method is nil but API.GetTags was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetTags.Lock()
+	mock.calls.GetTags = append(mock.calls.GetTags, callInfo)
+	mock.lockGetTags.Unlock()
+	return mock.GetTagsFunc(ctx)
+}
+
+// GetTagsCalls gets all the calls that were made to GetTags.
+// Check the length with:
+//
+//	len(mockedAPI.GetTagsCalls())
+func (mock *APIMock) GetTagsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetTags.RLock()
+	calls = mock.calls.GetTags
+	mock.lockGetTags.RUnlock()
+	return calls
+}
+
+// GetTransaction calls GetTransactionFunc.
+func (mock *APIMock) GetTransaction(ctx context.Context, id int64, filters *lunchmoney.TransactionFilters) (*lunchmoney.Transaction, error) {
+	if mock.GetTransactionFunc == nil {
+		panic("APIMock.GetTransactionFunc: method is nil but API.GetTransaction was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Filters *lunchmoney.TransactionFilters
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Filters: filters,
+	}
+	mock.lockGetTransaction.Lock()
+	mock.calls.GetTransaction = append(mock.calls.GetTransaction, callInfo)
+	mock.lockGetTransaction.Unlock()
+	return mock.GetTransactionFunc(ctx, id, filters)
+}
+
+// GetTransactionCalls gets all the calls that were made to GetTransaction.
+// Check the length with:
+//
+//	len(mockedAPI.GetTransactionCalls())
+func (mock *APIMock) GetTransactionCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Filters *lunchmoney.TransactionFilters
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Filters *lunchmoney.TransactionFilters
+	}
+	mock.lockGetTransaction.RLock()
+	calls = mock.calls.GetTransaction
+	mock.lockGetTransaction.RUnlock()
+	return calls
+}
+
+// GetTransactions calls GetTransactionsFunc.
+func (mock *APIMock) GetTransactions(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+	if mock.GetTransactionsFunc == nil {
+		panic("APIMock.GetTransactionsFunc: method is nil but API.GetTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Filters *lunchmoney.TransactionFilters
+	}{
+		Ctx:     ctx,
+		Filters: filters,
+	}
+	mock.lockGetTransactions.Lock()
+	mock.calls.GetTransactions = append(mock.calls.GetTransactions, callInfo)
+	mock.lockGetTransactions.Unlock()
+	return mock.GetTransactionsFunc(ctx, filters)
+}
+
+// GetTransactionsCalls gets all the calls that were made to GetTransactions.
+// Check the length with:
+//
+//	len(mockedAPI.GetTransactionsCalls())
+func (mock *APIMock) GetTransactionsCalls() []struct {
+	Ctx     context.Context
+	Filters *lunchmoney.TransactionFilters
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Filters *lunchmoney.TransactionFilters
+	}
+	mock.lockGetTransactions.RLock()
+	calls = mock.calls.GetTransactions
+	mock.lockGetTransactions.RUnlock()
+	return calls
+}
+
+// GetUser calls GetUserFunc.
+func (mock *APIMock) GetUser(ctx context.Context) (*lunchmoney.User, error) {
+	if mock.GetUserFunc == nil {
+		panic("APIMock.GetUserFunc: method is nil but API.GetUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetUser.Lock()
+	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
+	mock.lockGetUser.Unlock()
+	return mock.GetUserFunc(ctx)
+}
+
+// GetUserCalls gets all the calls that were made to GetUser.
+// Check the length with:
+//
+//	len(mockedAPI.GetUserCalls())
+func (mock *APIMock) GetUserCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetUser.RLock()
+	calls = mock.calls.GetUser
+	mock.lockGetUser.RUnlock()
+	return calls
+}
+
+// InsertTransactions calls InsertTransactionsFunc.
+func (mock *APIMock) InsertTransactions(ctx context.Context, itReq lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error) {
+	if mock.InsertTransactionsFunc == nil {
+		panic("APIMock.InsertTransactionsFunc: method is nil but API.InsertTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ItReq lunchmoney.InsertTransactionsRequest
+	}{
+		Ctx:   ctx,
+		ItReq: itReq,
+	}
+	mock.lockInsertTransactions.Lock()
+	mock.calls.InsertTransactions = append(mock.calls.InsertTransactions, callInfo)
+	mock.lockInsertTransactions.Unlock()
+	return mock.InsertTransactionsFunc(ctx, itReq)
+}
+
+// InsertTransactionsCalls gets all the calls that were made to InsertTransactions.
+// Check the length with:
+//
+//	len(mockedAPI.InsertTransactionsCalls())
+func (mock *APIMock) InsertTransactionsCalls() []struct {
+	Ctx   context.Context
+	ItReq lunchmoney.InsertTransactionsRequest
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ItReq lunchmoney.InsertTransactionsRequest
+	}
+	mock.lockInsertTransactions.RLock()
+	calls = mock.calls.InsertTransactions
+	mock.lockInsertTransactions.RUnlock()
+	return calls
+}
+
+// UpdateAsset calls UpdateAssetFunc.
+func (mock *APIMock) UpdateAsset(ctx context.Context, id int64, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error) {
+	if mock.UpdateAssetFunc == nil {
+		panic("APIMock.UpdateAssetFunc: method is nil but API.UpdateAsset was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    int64
+		Asset *lunchmoney.UpdateAsset
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Asset: asset,
+	}
+	mock.lockUpdateAsset.Lock()
+	mock.calls.UpdateAsset = append(mock.calls.UpdateAsset, callInfo)
+	mock.lockUpdateAsset.Unlock()
+	return mock.UpdateAssetFunc(ctx, id, asset)
+}
+
+// UpdateAssetCalls gets all the calls that were made to UpdateAsset.
+// Check the length with:
+//
+//	len(mockedAPI.UpdateAssetCalls())
+func (mock *APIMock) UpdateAssetCalls() []struct {
+	Ctx   context.Context
+	ID    int64
+	Asset *lunchmoney.UpdateAsset
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    int64
+		Asset *lunchmoney.UpdateAsset
+	}
+	mock.lockUpdateAsset.RLock()
+	calls = mock.calls.UpdateAsset
+	mock.lockUpdateAsset.RUnlock()
+	return calls
+}
+
+// UpdateTransaction calls UpdateTransactionFunc.
+func (mock *APIMock) UpdateTransaction(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+	if mock.UpdateTransactionFunc == nil {
+		panic("APIMock.UpdateTransactionFunc: method is nil but API.UpdateTransaction was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+		Ut  *lunchmoney.UpdateTransaction
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Ut:  ut,
+	}
+	mock.lockUpdateTransaction.Lock()
+	mock.calls.UpdateTransaction = append(mock.calls.UpdateTransaction, callInfo)
+	mock.lockUpdateTransaction.Unlock()
+	return mock.UpdateTransactionFunc(ctx, id, ut)
+}
+
+// UpdateTransactionCalls gets all the calls that were made to UpdateTransaction.
+// Check the length with:
+//
+//	len(mockedAPI.UpdateTransactionCalls())
+func (mock *APIMock) UpdateTransactionCalls() []struct {
+	Ctx context.Context
+	ID  int64
+	Ut  *lunchmoney.UpdateTransaction
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+		Ut  *lunchmoney.UpdateTransaction
+	}
+	mock.lockUpdateTransaction.RLock()
+	calls = mock.calls.UpdateTransaction
+	mock.lockUpdateTransaction.RUnlock()
+	return calls
+}
+
+// UpdateTransactionWithOptions calls UpdateTransactionWithOptionsFunc.
+func (mock *APIMock) UpdateTransactionWithOptions(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction, opts *lunchmoney.UpdateTransactionOptions) (*lunchmoney.UpdateTransactionResp, error) {
+	if mock.UpdateTransactionWithOptionsFunc == nil {
+		panic("APIMock.UpdateTransactionWithOptionsFunc: method is nil but API.UpdateTransactionWithOptions was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   int64
+		Ut   *lunchmoney.UpdateTransaction
+		Opts *lunchmoney.UpdateTransactionOptions
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Ut:   ut,
+		Opts: opts,
+	}
+	mock.lockUpdateTransactionWithOptions.Lock()
+	mock.calls.UpdateTransactionWithOptions = append(mock.calls.UpdateTransactionWithOptions, callInfo)
+	mock.lockUpdateTransactionWithOptions.Unlock()
+	return mock.UpdateTransactionWithOptionsFunc(ctx, id, ut, opts)
+}
+
+// UpdateTransactionWithOptionsCalls gets all the calls that were made to UpdateTransactionWithOptions.
+// Check the length with:
+//
+//	len(mockedAPI.UpdateTransactionWithOptionsCalls())
+func (mock *APIMock) UpdateTransactionWithOptionsCalls() []struct {
+	Ctx  context.Context
+	ID   int64
+	Ut   *lunchmoney.UpdateTransaction
+	Opts *lunchmoney.UpdateTransactionOptions
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   int64
+		Ut   *lunchmoney.UpdateTransaction
+		Opts *lunchmoney.UpdateTransactionOptions
+	}
+	mock.lockUpdateTransactionWithOptions.RLock()
+	calls = mock.calls.UpdateTransactionWithOptions
+	mock.lockUpdateTransactionWithOptions.RUnlock()
+	return calls
+}
+
+// UpdateTransactions calls UpdateTransactionsFunc.
+func (mock *APIMock) UpdateTransactions(ctx context.Context, updates map[int64]*lunchmoney.UpdateTransaction, opts *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error) {
+	if mock.UpdateTransactionsFunc == nil {
+		panic("APIMock.UpdateTransactionsFunc: method is nil but API.UpdateTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Updates map[int64]*lunchmoney.UpdateTransaction
+		Opts    *lunchmoney.BulkUpdateOptions
+	}{
+		Ctx:     ctx,
+		Updates: updates,
+		Opts:    opts,
+	}
+	mock.lockUpdateTransactions.Lock()
+	mock.calls.UpdateTransactions = append(mock.calls.UpdateTransactions, callInfo)
+	mock.lockUpdateTransactions.Unlock()
+	return mock.UpdateTransactionsFunc(ctx, updates, opts)
+}
+
+// UpdateTransactionsCalls gets all the calls that were made to UpdateTransactions.
+// Check the length with:
+//
+//	len(mockedAPI.UpdateTransactionsCalls())
+func (mock *APIMock) UpdateTransactionsCalls() []struct {
+	Ctx     context.Context
+	Updates map[int64]*lunchmoney.UpdateTransaction
+	Opts    *lunchmoney.BulkUpdateOptions
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Updates map[int64]*lunchmoney.UpdateTransaction
+		Opts    *lunchmoney.BulkUpdateOptions
+	}
+	mock.lockUpdateTransactions.RLock()
+	calls = mock.calls.UpdateTransactions
+	mock.lockUpdateTransactions.RUnlock()
+	return calls
+}
+
+// Ensure, that TransactionServiceMock does implement lunchmoney.TransactionService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.TransactionService = &TransactionServiceMock{}
+
+// TransactionServiceMock is a mock implementation of lunchmoney.TransactionService.
+//
+//	func TestSomethingThatUsesTransactionService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.TransactionService
+//		mockedTransactionService := &TransactionServiceMock{
+//			BulkUpdateFunc: func(ctx context.Context, updates map[int64]*lunchmoney.UpdateTransaction, opts *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error) {
+//				panic("mock out the BulkUpdate method")
+//			},
+//			CreateFunc: func(ctx context.Context, itReq lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error) {
+//				panic("mock out the Create method")
+//			},
+//			GetFunc: func(ctx context.Context, id int64, filters *lunchmoney.TransactionFilters) (*lunchmoney.Transaction, error) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+//				panic("mock out the List method")
+//			},
+//			UpdateFunc: func(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+//				panic("mock out the Update method")
+//			},
+//			UpdateWithOptionsFunc: func(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction, opts *lunchmoney.UpdateTransactionOptions) (*lunchmoney.UpdateTransactionResp, error) {
+//				panic("mock out the UpdateWithOptions method")
+//			},
+//		}
+//
+//		// use mockedTransactionService in code that requires lunchmoney.TransactionService
+//		// and then make assertions.
+//
+//	}
+type TransactionServiceMock struct {
+	// BulkUpdateFunc mocks the BulkUpdate method.
+	BulkUpdateFunc func(ctx context.Context, updates map[int64]*lunchmoney.UpdateTransaction, opts *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, itReq lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error)
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, id int64, filters *lunchmoney.TransactionFilters) (*lunchmoney.Transaction, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error)
+
+	// UpdateWithOptionsFunc mocks the UpdateWithOptions method.
+	UpdateWithOptionsFunc func(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction, opts *lunchmoney.UpdateTransactionOptions) (*lunchmoney.UpdateTransactionResp, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// BulkUpdate holds details about calls to the BulkUpdate method.
+		BulkUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Updates is the updates argument value.
+			Updates map[int64]*lunchmoney.UpdateTransaction
+			// Opts is the opts argument value.
+			Opts *lunchmoney.BulkUpdateOptions
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ItReq is the itReq argument value.
+			ItReq lunchmoney.InsertTransactionsRequest
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Filters is the filters argument value.
+			Filters *lunchmoney.TransactionFilters
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filters is the filters argument value.
+			Filters *lunchmoney.TransactionFilters
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Ut is the ut argument value.
+			Ut *lunchmoney.UpdateTransaction
+		}
+		// UpdateWithOptions holds details about calls to the UpdateWithOptions method.
+		UpdateWithOptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Ut is the ut argument value.
+			Ut *lunchmoney.UpdateTransaction
+			// Opts is the opts argument value.
+			Opts *lunchmoney.UpdateTransactionOptions
+		}
+	}
+	lockBulkUpdate        sync.RWMutex
+	lockCreate            sync.RWMutex
+	lockGet               sync.RWMutex
+	lockList              sync.RWMutex
+	lockUpdate            sync.RWMutex
+	lockUpdateWithOptions sync.RWMutex
+}
+
+// BulkUpdate calls BulkUpdateFunc.
+func (mock *TransactionServiceMock) BulkUpdate(ctx context.Context, updates map[int64]*lunchmoney.UpdateTransaction, opts *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error) {
+	if mock.BulkUpdateFunc == nil {
+		panic("TransactionServiceMock.BulkUpdateFunc: method is nil but TransactionService.BulkUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Updates map[int64]*lunchmoney.UpdateTransaction
+		Opts    *lunchmoney.BulkUpdateOptions
+	}{
+		Ctx:     ctx,
+		Updates: updates,
+		Opts:    opts,
+	}
+	mock.lockBulkUpdate.Lock()
+	mock.calls.BulkUpdate = append(mock.calls.BulkUpdate, callInfo)
+	mock.lockBulkUpdate.Unlock()
+	return mock.BulkUpdateFunc(ctx, updates, opts)
+}
+
+// BulkUpdateCalls gets all the calls that were made to BulkUpdate.
+// Check the length with:
+//
+//	len(mockedTransactionService.BulkUpdateCalls())
+func (mock *TransactionServiceMock) BulkUpdateCalls() []struct {
+	Ctx     context.Context
+	Updates map[int64]*lunchmoney.UpdateTransaction
+	Opts    *lunchmoney.BulkUpdateOptions
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Updates map[int64]*lunchmoney.UpdateTransaction
+		Opts    *lunchmoney.BulkUpdateOptions
+	}
+	mock.lockBulkUpdate.RLock()
+	calls = mock.calls.BulkUpdate
+	mock.lockBulkUpdate.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *TransactionServiceMock) Create(ctx context.Context, itReq lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error) {
+	if mock.CreateFunc == nil {
+		panic("TransactionServiceMock.CreateFunc: method is nil but TransactionService.Create was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ItReq lunchmoney.InsertTransactionsRequest
+	}{
+		Ctx:   ctx,
+		ItReq: itReq,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, itReq)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedTransactionService.CreateCalls())
+func (mock *TransactionServiceMock) CreateCalls() []struct {
+	Ctx   context.Context
+	ItReq lunchmoney.InsertTransactionsRequest
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ItReq lunchmoney.InsertTransactionsRequest
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *TransactionServiceMock) Get(ctx context.Context, id int64, filters *lunchmoney.TransactionFilters) (*lunchmoney.Transaction, error) {
+	if mock.GetFunc == nil {
+		panic("TransactionServiceMock.GetFunc: method is nil but TransactionService.Get was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Filters *lunchmoney.TransactionFilters
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Filters: filters,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, id, filters)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedTransactionService.GetCalls())
+func (mock *TransactionServiceMock) GetCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Filters *lunchmoney.TransactionFilters
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Filters *lunchmoney.TransactionFilters
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *TransactionServiceMock) List(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+	if mock.ListFunc == nil {
+		panic("TransactionServiceMock.ListFunc: method is nil but TransactionService.List was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Filters *lunchmoney.TransactionFilters
+	}{
+		Ctx:     ctx,
+		Filters: filters,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, filters)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedTransactionService.ListCalls())
+func (mock *TransactionServiceMock) ListCalls() []struct {
+	Ctx     context.Context
+	Filters *lunchmoney.TransactionFilters
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Filters *lunchmoney.TransactionFilters
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *TransactionServiceMock) Update(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+	if mock.UpdateFunc == nil {
+		panic("TransactionServiceMock.UpdateFunc: method is nil but TransactionService.Update was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+		Ut  *lunchmoney.UpdateTransaction
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Ut:  ut,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, id, ut)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedTransactionService.UpdateCalls())
+func (mock *TransactionServiceMock) UpdateCalls() []struct {
+	Ctx context.Context
+	ID  int64
+	Ut  *lunchmoney.UpdateTransaction
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+		Ut  *lunchmoney.UpdateTransaction
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// UpdateWithOptions calls UpdateWithOptionsFunc.
+func (mock *TransactionServiceMock) UpdateWithOptions(ctx context.Context, id int64, ut *lunchmoney.UpdateTransaction, opts *lunchmoney.UpdateTransactionOptions) (*lunchmoney.UpdateTransactionResp, error) {
+	if mock.UpdateWithOptionsFunc == nil {
+		panic("TransactionServiceMock.UpdateWithOptionsFunc: method is nil but TransactionService.UpdateWithOptions was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   int64
+		Ut   *lunchmoney.UpdateTransaction
+		Opts *lunchmoney.UpdateTransactionOptions
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Ut:   ut,
+		Opts: opts,
+	}
+	mock.lockUpdateWithOptions.Lock()
+	mock.calls.UpdateWithOptions = append(mock.calls.UpdateWithOptions, callInfo)
+	mock.lockUpdateWithOptions.Unlock()
+	return mock.UpdateWithOptionsFunc(ctx, id, ut, opts)
+}
+
+// UpdateWithOptionsCalls gets all the calls that were made to UpdateWithOptions.
+// Check the length with:
+//
+//	len(mockedTransactionService.UpdateWithOptionsCalls())
+func (mock *TransactionServiceMock) UpdateWithOptionsCalls() []struct {
+	Ctx  context.Context
+	ID   int64
+	Ut   *lunchmoney.UpdateTransaction
+	Opts *lunchmoney.UpdateTransactionOptions
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   int64
+		Ut   *lunchmoney.UpdateTransaction
+		Opts *lunchmoney.UpdateTransactionOptions
+	}
+	mock.lockUpdateWithOptions.RLock()
+	calls = mock.calls.UpdateWithOptions
+	mock.lockUpdateWithOptions.RUnlock()
+	return calls
+}
+
+// Ensure, that CategoryServiceMock does implement lunchmoney.CategoryService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.CategoryService = &CategoryServiceMock{}
+
+// CategoryServiceMock is a mock implementation of lunchmoney.CategoryService.
+//
+//	func TestSomethingThatUsesCategoryService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.CategoryService
+//		mockedCategoryService := &CategoryServiceMock{
+//			GetFunc: func(ctx context.Context, id int64) (*lunchmoney.Category, error) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func(ctx context.Context) ([]*lunchmoney.Category, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedCategoryService in code that requires lunchmoney.CategoryService
+//		// and then make assertions.
+//
+//	}
+type CategoryServiceMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, id int64) (*lunchmoney.Category, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]*lunchmoney.Category, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockGet  sync.RWMutex
+	lockList sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *CategoryServiceMock) Get(ctx context.Context, id int64) (*lunchmoney.Category, error) {
+	if mock.GetFunc == nil {
+		panic("CategoryServiceMock.GetFunc: method is nil but CategoryService.Get was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedCategoryService.GetCalls())
+func (mock *CategoryServiceMock) GetCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *CategoryServiceMock) List(ctx context.Context) ([]*lunchmoney.Category, error) {
+	if mock.ListFunc == nil {
+		panic("CategoryServiceMock.ListFunc: method is nil but CategoryService.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedCategoryService.ListCalls())
+func (mock *CategoryServiceMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Ensure, that TagServiceMock does implement lunchmoney.TagService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.TagService = &TagServiceMock{}
+
+// TagServiceMock is a mock implementation of lunchmoney.TagService.
+//
+//	func TestSomethingThatUsesTagService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.TagService
+//		mockedTagService := &TagServiceMock{
+//			ListFunc: func(ctx context.Context) ([]*lunchmoney.Tag, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedTagService in code that requires lunchmoney.TagService
+//		// and then make assertions.
+//
+//	}
+type TagServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]*lunchmoney.Tag, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockList sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *TagServiceMock) List(ctx context.Context) ([]*lunchmoney.Tag, error) {
+	if mock.ListFunc == nil {
+		panic("TagServiceMock.ListFunc: method is nil but TagService.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedTagService.ListCalls())
+func (mock *TagServiceMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Ensure, that BudgetServiceMock does implement lunchmoney.BudgetService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.BudgetService = &BudgetServiceMock{}
+
+// BudgetServiceMock is a mock implementation of lunchmoney.BudgetService.
+//
+//	func TestSomethingThatUsesBudgetService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.BudgetService
+//		mockedBudgetService := &BudgetServiceMock{
+//			ListFunc: func(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedBudgetService in code that requires lunchmoney.BudgetService
+//		// and then make assertions.
+//
+//	}
+type BudgetServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filters is the filters argument value.
+			Filters *lunchmoney.BudgetFilters
+		}
+	}
+	lockList sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *BudgetServiceMock) List(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	if mock.ListFunc == nil {
+		panic("BudgetServiceMock.ListFunc: method is nil but BudgetService.List was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Filters *lunchmoney.BudgetFilters
+	}{
+		Ctx:     ctx,
+		Filters: filters,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, filters)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedBudgetService.ListCalls())
+func (mock *BudgetServiceMock) ListCalls() []struct {
+	Ctx     context.Context
+	Filters *lunchmoney.BudgetFilters
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Filters *lunchmoney.BudgetFilters
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Ensure, that AssetServiceMock does implement lunchmoney.AssetService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.AssetService = &AssetServiceMock{}
+
+// AssetServiceMock is a mock implementation of lunchmoney.AssetService.
+//
+//	func TestSomethingThatUsesAssetService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.AssetService
+//		mockedAssetService := &AssetServiceMock{
+//			ListFunc: func(ctx context.Context) ([]*lunchmoney.Asset, error) {
+//				panic("mock out the List method")
+//			},
+//			UpdateFunc: func(ctx context.Context, id int64, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error) {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedAssetService in code that requires lunchmoney.AssetService
+//		// and then make assertions.
+//
+//	}
+type AssetServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]*lunchmoney.Asset, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, id int64, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Asset is the asset argument value.
+			Asset *lunchmoney.UpdateAsset
+		}
+	}
+	lockList   sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *AssetServiceMock) List(ctx context.Context) ([]*lunchmoney.Asset, error) {
+	if mock.ListFunc == nil {
+		panic("AssetServiceMock.ListFunc: method is nil but AssetService.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedAssetService.ListCalls())
+func (mock *AssetServiceMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *AssetServiceMock) Update(ctx context.Context, id int64, asset *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error) {
+	if mock.UpdateFunc == nil {
+		panic("AssetServiceMock.UpdateFunc: method is nil but AssetService.Update was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    int64
+		Asset *lunchmoney.UpdateAsset
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Asset: asset,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, id, asset)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedAssetService.UpdateCalls())
+func (mock *AssetServiceMock) UpdateCalls() []struct {
+	Ctx   context.Context
+	ID    int64
+	Asset *lunchmoney.UpdateAsset
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    int64
+		Asset *lunchmoney.UpdateAsset
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure, that PlaidAccountServiceMock does implement lunchmoney.PlaidAccountService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.PlaidAccountService = &PlaidAccountServiceMock{}
+
+// PlaidAccountServiceMock is a mock implementation of lunchmoney.PlaidAccountService.
+//
+//	func TestSomethingThatUsesPlaidAccountService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.PlaidAccountService
+//		mockedPlaidAccountService := &PlaidAccountServiceMock{
+//			ListFunc: func(ctx context.Context) ([]*lunchmoney.PlaidAccount, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedPlaidAccountService in code that requires lunchmoney.PlaidAccountService
+//		// and then make assertions.
+//
+//	}
+type PlaidAccountServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]*lunchmoney.PlaidAccount, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockList sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *PlaidAccountServiceMock) List(ctx context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	if mock.ListFunc == nil {
+		panic("PlaidAccountServiceMock.ListFunc: method is nil but PlaidAccountService.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedPlaidAccountService.ListCalls())
+func (mock *PlaidAccountServiceMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Ensure, that CryptoServiceMock does implement lunchmoney.CryptoService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.CryptoService = &CryptoServiceMock{}
+
+// CryptoServiceMock is a mock implementation of lunchmoney.CryptoService.
+//
+//	func TestSomethingThatUsesCryptoService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.CryptoService
+//		mockedCryptoService := &CryptoServiceMock{
+//			ListFunc: func(ctx context.Context) ([]*lunchmoney.Crypto, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedCryptoService in code that requires lunchmoney.CryptoService
+//		// and then make assertions.
+//
+//	}
+type CryptoServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]*lunchmoney.Crypto, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockList sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *CryptoServiceMock) List(ctx context.Context) ([]*lunchmoney.Crypto, error) {
+	if mock.ListFunc == nil {
+		panic("CryptoServiceMock.ListFunc: method is nil but CryptoService.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedCryptoService.ListCalls())
+func (mock *CryptoServiceMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Ensure, that RecurringExpenseServiceMock does implement lunchmoney.RecurringExpenseService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.RecurringExpenseService = &RecurringExpenseServiceMock{}
+
+// RecurringExpenseServiceMock is a mock implementation of lunchmoney.RecurringExpenseService.
+//
+//	func TestSomethingThatUsesRecurringExpenseService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.RecurringExpenseService
+//		mockedRecurringExpenseService := &RecurringExpenseServiceMock{
+//			ListFunc: func(ctx context.Context, filters *lunchmoney.RecurringExpenseFilters) ([]*lunchmoney.RecurringExpense, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedRecurringExpenseService in code that requires lunchmoney.RecurringExpenseService
+//		// and then make assertions.
+//
+//	}
+type RecurringExpenseServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, filters *lunchmoney.RecurringExpenseFilters) ([]*lunchmoney.RecurringExpense, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filters is the filters argument value.
+			Filters *lunchmoney.RecurringExpenseFilters
+		}
+	}
+	lockList sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *RecurringExpenseServiceMock) List(ctx context.Context, filters *lunchmoney.RecurringExpenseFilters) ([]*lunchmoney.RecurringExpense, error) {
+	if mock.ListFunc == nil {
+		panic("RecurringExpenseServiceMock.ListFunc: method is nil but RecurringExpenseService.List was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Filters *lunchmoney.RecurringExpenseFilters
+	}{
+		Ctx:     ctx,
+		Filters: filters,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, filters)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedRecurringExpenseService.ListCalls())
+func (mock *RecurringExpenseServiceMock) ListCalls() []struct {
+	Ctx     context.Context
+	Filters *lunchmoney.RecurringExpenseFilters
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Filters *lunchmoney.RecurringExpenseFilters
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Ensure, that UserServiceMock does implement lunchmoney.UserService.
+// If this is not the case, regenerate this file with moq.
+var _ lunchmoney.UserService = &UserServiceMock{}
+
+// UserServiceMock is a mock implementation of lunchmoney.UserService.
+//
+//	func TestSomethingThatUsesUserService(t *testing.T) {
+//
+//		// make and configure a mocked lunchmoney.UserService
+//		mockedUserService := &UserServiceMock{
+//			GetFunc: func(ctx context.Context) (*lunchmoney.User, error) {
+//				panic("mock out the Get method")
+//			},
+//		}
+//
+//		// use mockedUserService in code that requires lunchmoney.UserService
+//		// and then make assertions.
+//
+//	}
+type UserServiceMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context) (*lunchmoney.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockGet sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *UserServiceMock) Get(ctx context.Context) (*lunchmoney.User, error) {
+	if mock.GetFunc == nil {
+		panic("UserServiceMock.GetFunc: method is nil but UserService.Get was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedUserService.GetCalls())
+func (mock *UserServiceMock) GetCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
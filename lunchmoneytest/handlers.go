@@ -0,0 +1,1031 @@
+package lunchmoneytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+const dateFormat = "2006-01-02"
+
+func (s *Server) routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/me", s.getUser)
+
+	mux.HandleFunc("GET /v1/transactions", s.listTransactions)
+	mux.HandleFunc("GET /v1/transactions/{id}", s.getTransaction)
+	mux.HandleFunc("POST /v1/transactions", s.insertTransactions)
+	mux.HandleFunc("PUT /v1/transactions/{id}", s.updateTransaction)
+
+	mux.HandleFunc("GET /v1/categories", s.listCategories)
+	mux.HandleFunc("GET /v1/categories/{id}", s.getCategory)
+	mux.HandleFunc("POST /v1/categories", s.createCategory)
+	mux.HandleFunc("PUT /v1/categories/{id}", s.updateCategory)
+	mux.HandleFunc("DELETE /v1/categories/{id}", s.deleteCategory)
+	mux.HandleFunc("DELETE /v1/categories/{id}/force", s.deleteCategory)
+
+	mux.HandleFunc("GET /v1/tags", s.listTags)
+
+	mux.HandleFunc("GET /v1/assets", s.listAssets)
+	mux.HandleFunc("POST /v1/assets", s.createAsset)
+	mux.HandleFunc("PUT /v1/assets/{id}", s.updateAsset)
+
+	mux.HandleFunc("GET /v1/plaid_accounts", s.listPlaidAccounts)
+	mux.HandleFunc("GET /v1/crypto", s.listCrypto)
+	mux.HandleFunc("GET /v1/recurring_expenses", s.listRecurringExpenses)
+
+	mux.HandleFunc("GET /v1/budgets", s.listBudgets)
+	mux.HandleFunc("PUT /v1/budgets", s.upsertBudget)
+	mux.HandleFunc("DELETE /v1/budgets", s.deleteBudget)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	})
+}
+
+// id parses the {id} path value, writing an error if it isn't a number.
+func id(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	v, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%q is not a valid id", r.PathValue("id")))
+		return 0, false
+	}
+
+	return v, true
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// formatAmount normalizes amounts to the four decimals the API returns.
+func formatAmount(v string) (string, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid amount", v)
+	}
+
+	return strconv.FormatFloat(f, 'f', 4, 64), nil
+}
+
+func negate(amount string) string {
+	if strings.HasPrefix(amount, "-") {
+		return strings.TrimPrefix(amount, "-")
+	}
+
+	return "-" + amount
+}
+
+func (s *Server) getUser(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, s.data.User)
+}
+
+func (s *Server) transaction(id int64) *lunchmoney.Transaction {
+	for _, t := range s.data.Transactions {
+		if t.ID == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) category(id int64) *lunchmoney.Category {
+	for _, c := range s.data.Categories {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) asset(id int64) *lunchmoney.Asset {
+	for _, a := range s.data.Assets {
+		if a.ID == id {
+			return a
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) plaidAccount(id int64) *lunchmoney.PlaidAccount {
+	for _, p := range s.data.PlaidAccounts {
+		if p.ID == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// decorate fills in the fields the API derives from a transaction's
+// category and account.
+func (s *Server) decorate(t *lunchmoney.Transaction) {
+	t.CategoryName, t.CategoryGroupID, t.CategoryGroupName = "", 0, ""
+	t.IsIncome, t.ExcludeFromBudget, t.ExcludeFromTotals = false, false, false
+	if c := s.category(t.CategoryID); c != nil {
+		t.CategoryName = c.Name
+		t.IsIncome, t.ExcludeFromBudget, t.ExcludeFromTotals = c.IsIncome, c.ExcludeFromBudget, c.ExcludeFromTotals
+		if g := s.category(c.GroupID); g != nil {
+			t.CategoryGroupID, t.CategoryGroupName = g.ID, g.Name
+		}
+	}
+
+	t.AssetName, t.AssetDisplayName, t.AssetInstitutionName, t.AssetStatus = "", "", "", ""
+	t.PlaidAccountName, t.PlaidAccountDisplayName, t.PlaidAccountMask, t.InstitutionName = "", "", "", ""
+	t.AccountDisplayName = ""
+	if a := s.asset(t.AssetID); a != nil {
+		t.AssetName, t.AssetDisplayName, t.AssetInstitutionName, t.AssetStatus = a.Name, a.DisplayName, a.InstitutionName, a.Status
+		t.AccountDisplayName = a.DisplayName
+		if t.AccountDisplayName == "" {
+			t.AccountDisplayName = a.Name
+		}
+	}
+	if p := s.plaidAccount(t.PlaidAccountID); p != nil {
+		t.PlaidAccountName, t.PlaidAccountDisplayName, t.PlaidAccountMask, t.InstitutionName = p.Name, p.DisplayName, p.Mask, p.InstitutionName
+		t.AccountDisplayName = p.DisplayName
+		if t.AccountDisplayName == "" {
+			t.AccountDisplayName = p.Name
+		}
+	}
+}
+
+// listTransactions supports the API's filters. Unlike the API, every
+// transaction is returned when no date range is given, rather than just the
+// current month, so fixtures from any date are visible.
+func (s *Server) listTransactions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := r.URL.Query()
+	start, end := q.Get("start_date"), q.Get("end_date")
+	if (start == "") != (end == "") {
+		writeError(w, http.StatusBadRequest, "Both start_date and end_date must be specified.")
+		return
+	}
+
+	ids := map[string]int64{}
+	for _, k := range []string{"category_id", "asset_id", "plaid_account_id", "recurring_id", "tag_id", "offset", "limit"} {
+		if v := q.Get(k); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("%s must be a number", k))
+				return
+			}
+			ids[k] = n
+		}
+	}
+
+	matches := func(t *lunchmoney.Transaction) bool {
+		if start != "" && (t.Date < start || t.Date > end) {
+			return false
+		}
+
+		for k, v := range map[string]int64{
+			"category_id":      t.CategoryID,
+			"asset_id":         t.AssetID,
+			"plaid_account_id": t.PlaidAccountID,
+			"recurring_id":     t.RecurringID,
+		} {
+			if want, ok := ids[k]; ok && want != v {
+				return false
+			}
+		}
+
+		if want, ok := ids["tag_id"]; ok {
+			for _, tag := range t.Tags {
+				if int64(tag.ID) == want {
+					return true
+				}
+			}
+			return false
+		}
+
+		return true
+	}
+
+	ret := []*lunchmoney.Transaction{}
+	for _, t := range s.data.Transactions {
+		if matches(t) {
+			c := *t
+			if q.Get("debit_as_negative") == "true" {
+				c.Amount, c.ToBase = negate(c.Amount), -c.ToBase
+			}
+			ret = append(ret, &c)
+		}
+	}
+	sort.SliceStable(ret, func(i, k int) bool {
+		if ret[i].Date != ret[k].Date {
+			return ret[i].Date < ret[k].Date
+		}
+		return ret[i].ID < ret[k].ID
+	})
+
+	offset := min(int(ids["offset"]), len(ret))
+	ret = ret[offset:]
+	if limit, ok := ids["limit"]; ok && int(limit) < len(ret) {
+		ret = ret[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, &lunchmoney.TransactionsResponse{Transactions: ret})
+}
+
+func (s *Server) getTransaction(w http.ResponseWriter, r *http.Request) {
+	id, ok := id(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.transaction(id)
+	if t == nil {
+		writeError(w, http.StatusNotFound, "Transaction ID not found.")
+		return
+	}
+
+	c := *t
+	if r.URL.Query().Get("debit_as_negative") == "true" {
+		c.Amount, c.ToBase = negate(c.Amount), -c.ToBase
+	}
+
+	writeJSON(w, http.StatusOK, &c)
+}
+
+func (s *Server) insertTransactions(w http.ResponseWriter, r *http.Request) {
+	var req lunchmoney.InsertTransactionsRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var created []*lunchmoney.Transaction
+	for i, in := range req.Transactions {
+		if _, err := time.Parse(dateFormat, in.Date); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("transaction %d: date %q must be YYYY-MM-DD", i, in.Date))
+			return
+		}
+
+		amount, err := formatAmount(in.Amount)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("transaction %d: %v", i, err))
+			return
+		}
+		if req.DebitAsNegative {
+			amount = negate(amount)
+		}
+
+		if s.duplicate(in, amount, req.SkipDuplicates) {
+			continue
+		}
+
+		t := &lunchmoney.Transaction{
+			Date:         in.Date,
+			Payee:        in.Payee,
+			Amount:       amount,
+			Currency:     strings.ToLower(in.Currency),
+			Notes:        in.Notes,
+			Status:       in.Status,
+			ExternalID:   in.ExternalID,
+			OriginalName: in.Payee,
+			Source:       "api",
+			CreatedAt:    now(),
+			UpdatedAt:    now(),
+		}
+		if t.Currency == "" {
+			t.Currency = s.data.User.PrimaryCurrency
+		}
+		if t.Status == "" {
+			t.Status = "uncleared"
+		}
+
+		for _, ref := range []struct {
+			name string
+			id   *int64
+			dst  *int64
+			ok   func(int64) bool
+		}{
+			{"category_id", in.CategoryID, &t.CategoryID, func(id int64) bool { return s.category(id) != nil }},
+			{"asset_id", in.AssetID, &t.AssetID, func(id int64) bool { return s.asset(id) != nil }},
+			{"plaid_account_id", in.PlaidAccountID, &t.PlaidAccountID, func(id int64) bool { return s.plaidAccount(id) != nil }},
+			{"recurring_id", in.RecurringID, &t.RecurringID, func(int64) bool { return true }},
+		} {
+			if ref.id == nil {
+				continue
+			}
+			if !ref.ok(*ref.id) {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("transaction %d: %s %d does not exist", i, ref.name, *ref.id))
+				return
+			}
+			*ref.dst = *ref.id
+		}
+
+		for _, tagID := range in.TagsIDs {
+			tag := s.tagByID(int64(tagID))
+			if tag == nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("transaction %d: tag %d does not exist", i, tagID))
+				return
+			}
+			t.Tags = append(t.Tags, *tag)
+		}
+
+		created = append(created, t)
+	}
+
+	resp := &lunchmoney.InsertTransactionsResponse{IDs: []int64{}}
+	for _, t := range created {
+		t.ID = s.newID()
+		s.decorate(t)
+		s.data.Transactions = append(s.data.Transactions, t)
+		resp.IDs = append(resp.IDs, t.ID)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// duplicate reports whether in repeats a transaction in the same account.
+// As with the API, a matching external ID always counts, and with
+// skipDuplicates so does a matching date, payee and amount.
+func (s *Server) duplicate(in lunchmoney.InsertTransaction, amount string, skipDuplicates bool) bool {
+	for _, t := range s.data.Transactions {
+		if (in.AssetID != nil && *in.AssetID != t.AssetID) || (in.PlaidAccountID != nil && *in.PlaidAccountID != t.PlaidAccountID) {
+			continue
+		}
+
+		if in.ExternalID != "" && in.ExternalID == t.ExternalID {
+			return true
+		}
+
+		if skipDuplicates && in.Date == t.Date && in.Payee == t.Payee && amount == t.Amount {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) tagByID(id int64) *lunchmoney.Tag {
+	for _, t := range s.data.Tags {
+		if int64(t.ID) == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// tagByName finds a tag by name, creating it if it doesn't exist, as the API
+// does when tags are given by name.
+func (s *Server) tagByName(name string) *lunchmoney.Tag {
+	for _, t := range s.data.Tags {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+
+	t := &lunchmoney.Tag{ID: int(s.newID()), Name: name}
+	s.data.Tags = append(s.data.Tags, t)
+
+	return t
+}
+
+func (s *Server) updateTransaction(w http.ResponseWriter, r *http.Request) {
+	id, ok := id(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Transaction     map[string]json.RawMessage `json:"transaction"`
+		DebitAsNegative bool                       `json:"debit_as_negative"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orig := s.transaction(id)
+	if orig == nil {
+		writeError(w, http.StatusNotFound, "Transaction ID not found.")
+		return
+	}
+
+	// Apply to a copy so a bad field leaves the transaction untouched.
+	t := *orig
+	for field, raw := range req.Transaction {
+		if err := s.applyTransactionField(&t, field, raw, req.DebitAsNegative); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%s: %v", field, err))
+			return
+		}
+	}
+
+	t.UpdatedAt = now()
+	s.decorate(&t)
+	*orig = t
+
+	writeJSON(w, http.StatusOK, &lunchmoney.UpdateTransactionResp{Updated: true, Split: []int{}})
+}
+
+func (s *Server) applyTransactionField(t *lunchmoney.Transaction, field string, raw json.RawMessage, debitAsNegative bool) error {
+	null := string(raw) == "null"
+
+	str := func(dst *string) error {
+		if null {
+			*dst = ""
+			return nil
+		}
+		return json.Unmarshal(raw, dst)
+	}
+	ref := func(dst *int64, exists func(int64) bool) error {
+		if null {
+			*dst = 0
+			return nil
+		}
+
+		var v int64
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+		if !exists(v) {
+			return fmt.Errorf("%d does not exist", v)
+		}
+		*dst = v
+
+		return nil
+	}
+
+	switch field {
+	case "date":
+		var v string
+		if err := str(&v); err != nil {
+			return err
+		}
+		if _, err := time.Parse(dateFormat, v); err != nil {
+			return fmt.Errorf("%q must be YYYY-MM-DD", v)
+		}
+		t.Date = v
+	case "payee":
+		return str(&t.Payee)
+	case "notes":
+		return str(&t.Notes)
+	case "external_id":
+		return str(&t.ExternalID)
+	case "currency":
+		if err := str(&t.Currency); err != nil {
+			return err
+		}
+		t.Currency = strings.ToLower(t.Currency)
+	case "status":
+		var v string
+		if err := str(&v); err != nil {
+			return err
+		}
+		if v != "cleared" && v != "uncleared" {
+			return fmt.Errorf("%q must be cleared or uncleared", v)
+		}
+		t.Status = v
+	case "amount":
+		var v string
+		if err := str(&v); err != nil {
+			return err
+		}
+		amount, err := formatAmount(v)
+		if err != nil {
+			return err
+		}
+		if debitAsNegative {
+			amount = negate(amount)
+		}
+		t.Amount = amount
+	case "category_id":
+		return ref(&t.CategoryID, func(id int64) bool { return s.category(id) != nil })
+	case "asset_id":
+		return ref(&t.AssetID, func(id int64) bool { return s.asset(id) != nil })
+	case "plaid_account_id":
+		return ref(&t.PlaidAccountID, func(id int64) bool { return s.plaidAccount(id) != nil })
+	case "recurring_id":
+		return ref(&t.RecurringID, func(int64) bool { return true })
+	case "tags":
+		var refs []json.RawMessage
+		if !null {
+			if err := json.Unmarshal(raw, &refs); err != nil {
+				return err
+			}
+		}
+
+		tags := []lunchmoney.Tag{}
+		for _, r := range refs {
+			var name string
+			if err := json.Unmarshal(r, &name); err == nil {
+				tags = append(tags, *s.tagByName(name))
+				continue
+			}
+
+			var id int64
+			if err := json.Unmarshal(r, &id); err != nil {
+				return fmt.Errorf("%s is not a tag id or name", r)
+			}
+			tag := s.tagByID(id)
+			if tag == nil {
+				return fmt.Errorf("tag %d does not exist", id)
+			}
+			tags = append(tags, *tag)
+		}
+		t.Tags = tags
+	default:
+		return fmt.Errorf("unknown field")
+	}
+
+	return nil
+}
+
+func (s *Server) listCategories(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cats := append([]*lunchmoney.Category{}, s.data.Categories...)
+	sort.SliceStable(cats, func(i, k int) bool {
+		return strings.ToLower(cats[i].Name) < strings.ToLower(cats[k].Name)
+	})
+
+	writeJSON(w, http.StatusOK, &lunchmoney.CategoriesResponse{Categories: cats})
+}
+
+func (s *Server) getCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := id(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.category(id)
+	if c == nil {
+		writeError(w, http.StatusNotFound, "Category ID not found.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+// categoryRequest is the body of category create and update requests.
+type categoryRequest struct {
+	Name              *string `json:"name"`
+	Description       *string `json:"description"`
+	IsIncome          *bool   `json:"is_income"`
+	ExcludeFromBudget *bool   `json:"exclude_from_budget"`
+	ExcludeFromTotals *bool   `json:"exclude_from_totals"`
+	GroupID           *int64  `json:"group_id"`
+}
+
+func (s *Server) applyCategory(c *lunchmoney.Category, req *categoryRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 40 {
+			return fmt.Errorf("name must be between 1 and 40 characters")
+		}
+		for _, other := range s.data.Categories {
+			if other.ID != c.ID && strings.EqualFold(other.Name, name) {
+				return fmt.Errorf("a category named %q already exists", name)
+			}
+		}
+		c.Name = name
+	}
+
+	if req.GroupID != nil {
+		if g := s.category(*req.GroupID); *req.GroupID != 0 && (g == nil || !g.IsGroup) {
+			return fmt.Errorf("group %d does not exist", *req.GroupID)
+		}
+		c.GroupID = *req.GroupID
+	}
+
+	if req.Description != nil {
+		c.Description = *req.Description
+	}
+	if req.IsIncome != nil {
+		c.IsIncome = *req.IsIncome
+	}
+	if req.ExcludeFromBudget != nil {
+		c.ExcludeFromBudget = *req.ExcludeFromBudget
+	}
+	if req.ExcludeFromTotals != nil {
+		c.ExcludeFromTotals = *req.ExcludeFromTotals
+	}
+	c.UpdatedAt = time.Now().UTC()
+
+	return nil
+}
+
+func (s *Server) createCategory(w http.ResponseWriter, r *http.Request) {
+	var req categoryRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Name == nil {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	c := &lunchmoney.Category{ID: s.newID(), CreatedAt: time.Now().UTC()}
+	if err := s.applyCategory(c, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.data.Categories = append(s.data.Categories, c)
+
+	writeJSON(w, http.StatusOK, map[string]int64{"category_id": c.ID})
+}
+
+func (s *Server) updateCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := id(w, r)
+	if !ok {
+		return
+	}
+
+	var req categoryRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orig := s.category(id)
+	if orig == nil {
+		writeError(w, http.StatusNotFound, "Category ID not found.")
+		return
+	}
+
+	c := *orig
+	if err := s.applyCategory(&c, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	*orig = c
+
+	for _, t := range s.data.Transactions {
+		s.decorate(t)
+	}
+
+	writeJSON(w, http.StatusOK, true)
+}
+
+// deleteCategory refuses to delete a category that transactions, budgets or
+// other categories depend on, unless the /force endpoint was used.
+func (s *Server) deleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := id(w, r)
+	if !ok {
+		return
+	}
+	force := strings.HasSuffix(r.URL.Path, "/force")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.category(id) == nil {
+		writeError(w, http.StatusNotFound, "Category ID not found.")
+		return
+	}
+
+	dependents := 0
+	for _, t := range s.data.Transactions {
+		if t.CategoryID == id {
+			dependents++
+		}
+	}
+	for _, c := range s.data.Categories {
+		if c.GroupID == id {
+			dependents++
+		}
+	}
+	if dependents > 0 && !force {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("category %d has %d dependents; use the force endpoint to delete it anyway", id, dependents))
+		return
+	}
+
+	cats := s.data.Categories[:0]
+	for _, c := range s.data.Categories {
+		if c.ID == id {
+			continue
+		}
+		if c.GroupID == id {
+			c.GroupID = 0
+		}
+		cats = append(cats, c)
+	}
+	s.data.Categories = cats
+
+	budgets := s.data.Budgets[:0]
+	for _, b := range s.data.Budgets {
+		if int64(b.CategoryID) != id {
+			budgets = append(budgets, b)
+		}
+	}
+	s.data.Budgets = budgets
+
+	for _, t := range s.data.Transactions {
+		if t.CategoryID == id {
+			t.CategoryID = 0
+		}
+		s.decorate(t)
+	}
+
+	writeJSON(w, http.StatusOK, true)
+}
+
+func (s *Server) listTags(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, append([]*lunchmoney.Tag{}, s.data.Tags...))
+}
+
+func (s *Server) listAssets(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &lunchmoney.AssetsResponse{Assets: append([]*lunchmoney.Asset{}, s.data.Assets...)})
+}
+
+// applyAsset applies the set fields of an asset request.
+func (s *Server) applyAsset(a *lunchmoney.Asset, req *lunchmoney.UpdateAsset) error {
+	if req.Balance != nil {
+		balance, err := formatAmount(*req.Balance)
+		if err != nil {
+			return err
+		}
+		a.Balance = balance
+		a.BalanceAsOf = time.Now().UTC()
+	}
+
+	if req.BalanceAsOf != nil {
+		asOf, err := time.Parse(time.RFC3339, *req.BalanceAsOf)
+		if err != nil {
+			return fmt.Errorf("balance_as_of %q must be RFC 3339", *req.BalanceAsOf)
+		}
+		a.BalanceAsOf = asOf
+	}
+
+	for _, f := range []struct {
+		v   *string
+		dst *string
+	}{
+		{req.TypeName, &a.TypeName},
+		{req.SubtypeName, &a.SubtypeName},
+		{req.Name, &a.Name},
+		{req.DisplayName, &a.DisplayName},
+		{req.InstitutionName, &a.InstitutionName},
+	} {
+		if f.v != nil {
+			*f.dst = *f.v
+		}
+	}
+
+	if req.Currency != nil {
+		a.Currency = strings.ToLower(*req.Currency)
+	}
+
+	if req.ClosedOn != nil {
+		a.Status = "closed"
+	}
+
+	return nil
+}
+
+func (s *Server) createAsset(w http.ResponseWriter, r *http.Request) {
+	var req lunchmoney.UpdateAsset
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.TypeName == nil || req.Name == nil || req.Balance == nil {
+		writeError(w, http.StatusBadRequest, "type_name, name and balance are required")
+		return
+	}
+
+	a := &lunchmoney.Asset{ID: s.newID(), Currency: s.data.User.PrimaryCurrency, Status: "active", CreatedAt: time.Now().UTC()}
+	if err := s.applyAsset(a, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.data.Assets = append(s.data.Assets, a)
+
+	writeJSON(w, http.StatusOK, a)
+}
+
+func (s *Server) updateAsset(w http.ResponseWriter, r *http.Request) {
+	id, ok := id(w, r)
+	if !ok {
+		return
+	}
+
+	var req lunchmoney.UpdateAsset
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orig := s.asset(id)
+	if orig == nil {
+		writeError(w, http.StatusNotFound, "Asset ID not found.")
+		return
+	}
+
+	a := *orig
+	if err := s.applyAsset(&a, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	*orig = a
+
+	for _, t := range s.data.Transactions {
+		if t.AssetID == id {
+			s.decorate(t)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, &a)
+}
+
+func (s *Server) listPlaidAccounts(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &lunchmoney.PlaidAccountsResponse{PlaidAccounts: append([]*lunchmoney.PlaidAccount{}, s.data.PlaidAccounts...)})
+}
+
+func (s *Server) listCrypto(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &lunchmoney.CryptoResponse{Crypto: append([]*lunchmoney.Crypto{}, s.data.Crypto...)})
+}
+
+func (s *Server) listRecurringExpenses(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &lunchmoney.RecurringExpensesResponse{
+		RecurringExpenses: append([]*lunchmoney.RecurringExpense{}, s.data.RecurringExpenses...),
+	})
+}
+
+func (s *Server) listBudgets(w http.ResponseWriter, r *http.Request) {
+	start, end := r.URL.Query().Get("start_date"), r.URL.Query().Get("end_date")
+	if start == "" || end == "" {
+		writeError(w, http.StatusBadRequest, "start_date and end_date are required")
+		return
+	}
+	if end <= start {
+		writeError(w, http.StatusBadRequest, "end_date cannot be same or before start_date")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := []*lunchmoney.Budget{}
+	for _, b := range s.data.Budgets {
+		c := *b
+		c.Data = map[string]*lunchmoney.BudgetData{}
+		for month, d := range b.Data {
+			if month >= start[:8]+"01" && month <= end {
+				c.Data[month] = d
+			}
+		}
+		ret = append(ret, &c)
+	}
+
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *Server) upsertBudget(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		StartDate  string      `json:"start_date"`
+		CategoryID int64       `json:"category_id"`
+		Amount     json.Number `json:"amount"`
+		Currency   string      `json:"currency"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	month, err := time.Parse(dateFormat, req.StartDate)
+	if err != nil || month.Day() != 1 {
+		writeError(w, http.StatusBadRequest, "start_date must be the first day of a month")
+		return
+	}
+	amount, err := req.Amount.Float64()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "amount must be a number")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.category(req.CategoryID)
+	if c == nil {
+		writeError(w, http.StatusNotFound, "Category ID not found.")
+		return
+	}
+
+	var budget *lunchmoney.Budget
+	for _, b := range s.data.Budgets {
+		if int64(b.CategoryID) == req.CategoryID {
+			budget = b
+		}
+	}
+	if budget == nil {
+		budget = &lunchmoney.Budget{
+			CategoryID:        int(c.ID),
+			CategoryName:      c.Name,
+			GroupID:           int(c.GroupID),
+			IsGroup:           c.IsGroup,
+			IsIncome:          c.IsIncome,
+			ExcludeFromBudget: c.ExcludeFromBudget,
+			ExcludeFromTotals: c.ExcludeFromTotals,
+			Data:              map[string]*lunchmoney.BudgetData{},
+		}
+		s.data.Budgets = append(s.data.Budgets, budget)
+	}
+	if budget.Data == nil {
+		budget.Data = map[string]*lunchmoney.BudgetData{}
+	}
+
+	currency := strings.ToLower(req.Currency)
+	if currency == "" {
+		currency = s.data.User.PrimaryCurrency
+	}
+
+	d := budget.Data[req.StartDate]
+	if d == nil {
+		d = &lunchmoney.BudgetData{BudgetMonth: req.StartDate}
+		budget.Data[req.StartDate] = d
+	}
+	d.BudgetAmount = req.Amount
+	d.BudgetCurrency = currency
+	d.BudgetToBase = amount
+
+	writeJSON(w, http.StatusOK, map[string]any{"category_group": nil})
+}
+
+func (s *Server) deleteBudget(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	start := q.Get("start_date")
+	categoryID, err := strconv.ParseInt(q.Get("category_id"), 10, 64)
+	if start == "" || err != nil {
+		writeError(w, http.StatusBadRequest, "start_date and category_id are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, b := range s.data.Budgets {
+		if int64(b.CategoryID) == categoryID {
+			delete(b.Data, start)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, true)
+}
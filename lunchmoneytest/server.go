@@ -0,0 +1,306 @@
+// Package lunchmoneytest provides an in-process fake Lunch Money API for
+// tests. The fake is stateful: transactions inserted through it can be read
+// back, categories can be created and deleted, and so on. It starts out
+// seeded with the same fixtures the lunchmoney package tests against.
+//
+//	srv := lunchmoneytest.NewServer(nil)
+//	defer srv.Close()
+//	client := srv.Client()
+//
+// Faults such as latency, rate limiting and server errors can be injected
+// with Server.Inject.
+package lunchmoneytest
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+//go:embed testdata/*.json
+var fixtures embed.FS
+
+// Token is the API token the fake server accepts.
+const Token = "lunchmoneytest-token"
+
+// Data is the state of a fake Lunch Money account.
+type Data struct {
+	User              *lunchmoney.User
+	Transactions      []*lunchmoney.Transaction
+	Categories        []*lunchmoney.Category
+	Tags              []*lunchmoney.Tag
+	Assets            []*lunchmoney.Asset
+	PlaidAccounts     []*lunchmoney.PlaidAccount
+	Crypto            []*lunchmoney.Crypto
+	RecurringExpenses []*lunchmoney.RecurringExpense
+	Budgets           []*lunchmoney.Budget
+}
+
+// Fixtures returns the data a server is seeded with by default.
+func Fixtures() (*Data, error) {
+	d := &Data{User: &lunchmoney.User{}}
+	files := []struct {
+		name string
+		v    any
+	}{
+		{"user.json", d.User},
+		{"transactions.json", &lunchmoney.TransactionsResponse{}},
+		{"categories.json", &lunchmoney.CategoriesResponse{}},
+		{"tags.json", &d.Tags},
+		{"assets.json", &lunchmoney.AssetsResponse{}},
+		{"plaid.json", &lunchmoney.PlaidAccountsResponse{}},
+		{"crypto.json", &lunchmoney.CryptoResponse{}},
+		{"recurring.json", &lunchmoney.RecurringExpensesResponse{}},
+		{"budgets.json", &d.Budgets},
+	}
+
+	for _, f := range files {
+		b, err := fixtures.ReadFile("testdata/" + f.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(b, f.v); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", f.name, err)
+		}
+
+		switch v := f.v.(type) {
+		case *lunchmoney.TransactionsResponse:
+			d.Transactions = v.Transactions
+		case *lunchmoney.CategoriesResponse:
+			d.Categories = v.Categories
+		case *lunchmoney.AssetsResponse:
+			d.Assets = v.Assets
+		case *lunchmoney.PlaidAccountsResponse:
+			d.PlaidAccounts = v.PlaidAccounts
+		case *lunchmoney.CryptoResponse:
+			d.Crypto = v.Crypto
+		case *lunchmoney.RecurringExpensesResponse:
+			d.RecurringExpenses = v.RecurringExpenses
+		}
+	}
+
+	return d, nil
+}
+
+// Fault makes matching requests misbehave.
+type Fault struct {
+	// Method and Path select the requests to affect. Empty values match
+	// every request, and a Path ending in "/" matches everything under it.
+	Method string
+	Path   string
+	// Times is how many requests the fault affects. Zero means every
+	// matching request until ClearFaults.
+	Times int
+	// Latency delays the response.
+	Latency time.Duration
+	// Status, if set, is returned instead of handling the request, with an
+	// error body. A 429 also sets Retry-After to RetryAfter, rounded up to
+	// whole seconds.
+	Status     int
+	RetryAfter time.Duration
+	// Malformed returns a 200 with a truncated JSON body.
+	Malformed bool
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+
+	if strings.HasSuffix(f.Path, "/") {
+		return strings.HasPrefix(r.URL.Path, f.Path)
+	}
+
+	return f.Path == "" || f.Path == r.URL.Path
+}
+
+// Request is a request the server received.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Server is a fake Lunch Money API.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	data     *Data
+	nextID   int64
+	faults   []*Fault
+	requests []Request
+}
+
+// NewServer starts a fake API seeded with data, or with Fixtures when data
+// is nil. The server keeps its own copy of data. Like httptest.NewServer, it
+// panics if it can't start.
+func NewServer(data *Data) *Server {
+	if data == nil {
+		var err error
+		if data, err = Fixtures(); err != nil {
+			panic(fmt.Sprintf("lunchmoneytest: load fixtures: %v", err))
+		}
+	}
+
+	s := &Server{data: copyData(data), nextID: 1_000_000}
+	if s.data.User == nil {
+		s.data.User = &lunchmoney.User{PrimaryCurrency: "usd"}
+	}
+
+	mux := http.NewServeMux()
+	s.routes(mux)
+	s.Server = httptest.NewServer(s.middleware(mux))
+
+	return s
+}
+
+// Client returns a client that talks to the fake server.
+func (s *Server) Client() *lunchmoney.Client {
+	client, err := lunchmoney.NewClient(Token)
+	if err != nil {
+		panic(fmt.Sprintf("lunchmoneytest: %v", err))
+	}
+
+	if client.Base, err = url.Parse(s.URL); err != nil {
+		panic(fmt.Sprintf("lunchmoneytest: %v", err))
+	}
+
+	return client
+}
+
+// Data returns a copy of the server's current state.
+func (s *Server) Data() *Data {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return copyData(s.data)
+}
+
+// Inject adds a fault. Faults are checked in the order they were added.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// fault records r and returns the first fault matching it, if any.
+func (s *Server) fault(r *http.Request, body []byte) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+
+	for i, f := range s.faults {
+		if !f.matches(r) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "could not read body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		if f := s.fault(r, body); f != nil {
+			if f.Latency > 0 {
+				select {
+				case <-time.After(f.Latency):
+				case <-r.Context().Done():
+					return
+				}
+			}
+
+			switch {
+			case f.Status == http.StatusTooManyRequests:
+				w.Header().Set("Retry-After", fmt.Sprint(int((f.RetryAfter+time.Second-1)/time.Second)))
+				writeError(w, f.Status, "Too Many Requests")
+				return
+			case f.Status != 0:
+				writeError(w, f.Status, http.StatusText(f.Status))
+				return
+			case f.Malformed:
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"error": nul`))
+				return
+			}
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "Access token does not exist.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// copyData deep copies d through JSON, so callers can't alias server state.
+func copyData(d *Data) *Data {
+	b, err := json.Marshal(d)
+	if err != nil {
+		panic(fmt.Sprintf("lunchmoneytest: copy data: %v", err))
+	}
+
+	ret := &Data{}
+	if err := json.Unmarshal(b, ret); err != nil {
+		panic(fmt.Sprintf("lunchmoneytest: copy data: %v", err))
+	}
+
+	return ret
+}
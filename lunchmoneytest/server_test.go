@@ -0,0 +1,244 @@
+package lunchmoneytest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestFixtures(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+
+	user, err := client.User.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "usd", user.PrimaryCurrency)
+
+	txns, err := client.Transactions.List(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, txns, 2)
+
+	cats, err := client.Categories.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, cats, 2)
+
+	tags, err := client.Tags.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tags, 2)
+
+	assets, err := client.Assets.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, assets, 4)
+
+	plaid, err := client.Plaid.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, plaid, 2)
+
+	crypto, err := client.Crypto.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, crypto, 2)
+
+	budgets, err := client.Budgets.List(ctx, &lunchmoney.BudgetFilters{StartDate: "2021-01-01", EndDate: "2021-01-31"})
+	require.NoError(t, err)
+	require.NotEmpty(t, budgets)
+	for _, b := range budgets {
+		for month := range b.Data {
+			assert.Equal(t, "2021-01-01", month)
+		}
+	}
+
+	_, err = client.Transactions.Get(ctx, 1, nil)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestTransactions(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+
+	resp, err := client.Transactions.Create(ctx, lunchmoney.InsertTransactionsRequest{
+		SkipDuplicates: true,
+		Transactions: []lunchmoney.InsertTransaction{
+			{Date: "2023-03-01", Payee: "Blue Bottle", Amount: "4.5", CategoryID: ptr(int64(83)), AssetID: ptr(int64(72)), ExternalID: "bb-1", Status: "cleared", TagsIDs: []int{1}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.IDs, 1)
+	id := resp.IDs[0]
+
+	txn, err := client.Transactions.Get(ctx, id, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "4.5000", txn.Amount)
+	assert.Equal(t, "usd", txn.Currency)
+	assert.Equal(t, int64(83), txn.CategoryID)
+	assert.NotEmpty(t, txn.CategoryName)
+	assert.NotEmpty(t, txn.AssetName)
+	require.Len(t, txn.Tags, 1)
+	assert.Equal(t, "Vacation", txn.Tags[0].Name)
+
+	// The same external ID in the same account is always skipped.
+	resp, err = client.Transactions.Create(ctx, lunchmoney.InsertTransactionsRequest{
+		Transactions: []lunchmoney.InsertTransaction{
+			{Date: "2023-03-02", Payee: "Blue Bottle", Amount: "5", AssetID: ptr(int64(72)), ExternalID: "bb-1", Status: "cleared"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.IDs)
+
+	// With skip_duplicates, so is the same date, payee and amount.
+	resp, err = client.Transactions.Create(ctx, lunchmoney.InsertTransactionsRequest{
+		SkipDuplicates: true,
+		Transactions: []lunchmoney.InsertTransaction{
+			{Date: "2023-03-01", Payee: "Blue Bottle", Amount: "4.50", AssetID: ptr(int64(72)), Status: "cleared"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.IDs)
+
+	_, err = client.Transactions.Update(ctx, id, &lunchmoney.UpdateTransaction{
+		CategoryID: lunchmoney.Clear[int64](),
+		Payee:      ptr("Blue Bottle Coffee"),
+		Tags:       []lunchmoney.TagRef{lunchmoney.TagByName("coffee")},
+	})
+	require.NoError(t, err)
+
+	txn, err = client.Transactions.Get(ctx, id, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Blue Bottle Coffee", txn.Payee)
+	assert.Zero(t, txn.CategoryID)
+	assert.Empty(t, txn.CategoryName)
+	require.Len(t, txn.Tags, 1)
+	assert.Equal(t, "coffee", txn.Tags[0].Name)
+
+	tags, err := client.Tags.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tags, 3, "tags referenced by a new name are created")
+
+	txns, err := client.Transactions.List(ctx, &lunchmoney.TransactionFilters{
+		StartDate:       ptr("2023-03-01"),
+		EndDate:         ptr("2023-03-31"),
+		DebitAsNegative: ptr(true),
+	})
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	assert.Equal(t, "-4.5000", txns[0].Amount)
+
+	_, err = client.Transactions.Update(ctx, id, &lunchmoney.UpdateTransaction{CategoryID: lunchmoney.Set(int64(1))})
+	assert.ErrorContains(t, err, "does not exist")
+}
+
+func TestCategories(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+
+	body, err := client.Post(ctx, "/v1/categories", map[string]any{"name": "Coffee Shops"})
+	require.NoError(t, err)
+	var created struct {
+		CategoryID int64 `json:"category_id"`
+	}
+	require.NoError(t, json.NewDecoder(body).Decode(&created))
+
+	cat, err := client.Categories.Get(ctx, created.CategoryID)
+	require.NoError(t, err)
+	assert.Equal(t, "Coffee Shops", cat.Name)
+
+	_, err = client.Post(ctx, "/v1/categories", map[string]any{"name": "coffee shops"})
+	assert.ErrorContains(t, err, "already exists")
+
+	_, err = client.Transactions.Create(ctx, lunchmoney.InsertTransactionsRequest{
+		Transactions: []lunchmoney.InsertTransaction{
+			{Date: "2023-03-01", Payee: "Blue Bottle", Amount: "4.5", CategoryID: &created.CategoryID, Status: "uncleared"},
+		},
+	})
+	require.NoError(t, err)
+
+	// Deleting a category in use needs the force endpoint.
+	del := func(path string) (int, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, srv.URL+path, nil)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := client.HTTP.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		_, err = io.Copy(io.Discard, resp.Body)
+
+		return resp.StatusCode, err
+	}
+
+	status, err := del("/v1/categories/" + strconv.FormatInt(created.CategoryID, 10))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, err = del("/v1/categories/" + strconv.FormatInt(created.CategoryID, 10) + "/force")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	_, err = client.Categories.Get(ctx, created.CategoryID)
+	assert.Error(t, err)
+	for _, txn := range srv.Data().Transactions {
+		assert.NotEqual(t, created.CategoryID, txn.CategoryID)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+
+	srv.Inject(Fault{Path: "/v1/tags", Times: 1, Status: http.StatusTooManyRequests, RetryAfter: 1500 * time.Millisecond})
+	resp, err := client.HTTP.Get(srv.URL + "/v1/tags")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+
+	_, err = client.Tags.List(ctx)
+	assert.NoError(t, err, "the fault only applied once")
+
+	srv.Inject(Fault{Path: "/v1/", Status: http.StatusInternalServerError})
+	_, err = client.Assets.List(ctx)
+	assert.Error(t, err)
+	_, err = client.Categories.List(ctx)
+	assert.Error(t, err)
+	srv.ClearFaults()
+
+	srv.Inject(Fault{Method: http.MethodGet, Path: "/v1/me", Malformed: true})
+	_, err = client.User.Get(ctx)
+	assert.Error(t, err)
+	srv.ClearFaults()
+
+	srv.Inject(Fault{Latency: time.Second})
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = client.User.Get(timeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	srv.ClearFaults()
+
+	resp, err = http.Get(srv.URL + "/v1/me")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	assert.NotEmpty(t, srv.Requests())
+}
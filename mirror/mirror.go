@@ -0,0 +1,196 @@
+// Package mirror keeps a local SQLite copy of a Lunch Money account.
+//
+// Sync mirrors transactions, categories, tags, assets, Plaid accounts and
+// budgets. Each table has a column per field, so the database can be queried
+// directly with sqlite3, DuckDB or any SQL tool, and a raw column holding the
+// API's JSON. A Mirror also serves the read side of the API's services, such
+// as Mirror.Transactions, which can replace a Client's so code written
+// against the API can run offline.
+package mirror
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	// Registers the "sqlite" driver.
+	_ "modernc.org/sqlite"
+)
+
+var (
+	// ErrNotFound is returned when a record isn't in the mirror.
+	ErrNotFound = errors.New("not found in mirror")
+	// ErrReadOnly is returned by the mirror's services for writes.
+	ErrReadOnly = errors.New("mirror is read-only")
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS transactions (
+	id                  INTEGER PRIMARY KEY,
+	date                TEXT NOT NULL,
+	payee               TEXT,
+	original_name       TEXT,
+	amount              NUMERIC,
+	currency            TEXT,
+	to_base             REAL,
+	category_id         INTEGER,
+	category_name       TEXT,
+	category_group_id   INTEGER,
+	category_group_name TEXT,
+	is_income           BOOLEAN,
+	exclude_from_budget BOOLEAN,
+	exclude_from_totals BOOLEAN,
+	notes               TEXT,
+	status              TEXT,
+	is_pending          BOOLEAN,
+	asset_id            INTEGER,
+	plaid_account_id    INTEGER,
+	recurring_id        INTEGER,
+	parent_id           INTEGER,
+	group_id            INTEGER,
+	is_group            BOOLEAN,
+	external_id         TEXT,
+	tags                TEXT,
+	created_at          TEXT,
+	updated_at          TEXT,
+	raw                 TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS transactions_date ON transactions (date);
+CREATE INDEX IF NOT EXISTS transactions_category ON transactions (category_id);
+CREATE INDEX IF NOT EXISTS transactions_asset ON transactions (asset_id);
+CREATE INDEX IF NOT EXISTS transactions_plaid_account ON transactions (plaid_account_id);
+
+CREATE TABLE IF NOT EXISTS categories (
+	id                  INTEGER PRIMARY KEY,
+	name                TEXT,
+	description         TEXT,
+	is_income           BOOLEAN,
+	exclude_from_budget BOOLEAN,
+	exclude_from_totals BOOLEAN,
+	is_group            BOOLEAN,
+	group_id            INTEGER,
+	created_at          TEXT,
+	updated_at          TEXT,
+	raw                 TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+	id          INTEGER PRIMARY KEY,
+	name        TEXT,
+	description TEXT,
+	raw         TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS assets (
+	id               INTEGER PRIMARY KEY,
+	type_name        TEXT,
+	subtype_name     TEXT,
+	name             TEXT,
+	display_name     TEXT,
+	balance          NUMERIC,
+	balance_as_of    TEXT,
+	to_base          REAL,
+	currency         TEXT,
+	status           TEXT,
+	institution_name TEXT,
+	created_at       TEXT,
+	raw              TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS plaid_accounts (
+	id                  INTEGER PRIMARY KEY,
+	name                TEXT,
+	display_name        TEXT,
+	type                TEXT,
+	subtype             TEXT,
+	mask                TEXT,
+	institution_name    TEXT,
+	status              TEXT,
+	balance             NUMERIC,
+	to_base             REAL,
+	currency            TEXT,
+	balance_last_update TEXT,
+	last_import         TEXT,
+	raw                 TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS budgets (
+	category_id         INTEGER NOT NULL,
+	month               TEXT NOT NULL,
+	category_name       TEXT,
+	category_group_name TEXT,
+	group_id            INTEGER,
+	is_group            BOOLEAN,
+	is_income           BOOLEAN,
+	budget_amount       NUMERIC,
+	budget_currency     TEXT,
+	budget_to_base      REAL,
+	spending_to_base    REAL,
+	num_transactions    INTEGER,
+	raw                 TEXT NOT NULL,
+	PRIMARY KEY (category_id, month)
+);
+
+CREATE TABLE IF NOT EXISTS sync_state (
+	resource   TEXT PRIMARY KEY,
+	start_date TEXT NOT NULL,
+	synced_at  TEXT NOT NULL
+);
+`
+
+// Mirror is a local SQLite copy of a Lunch Money account.
+type Mirror struct {
+	// DB is the underlying database, for queries the services don't cover.
+	DB *sql.DB
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Open opens or creates a mirror at path.
+func Open(path string) (*Mirror, error) {
+	dsn := "file:" + path + "?" + url.Values{"_pragma": {"busy_timeout(5000)", "journal_mode(wal)", "foreign_keys(on)"}}.Encode()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+
+	return &Mirror{DB: db, Now: time.Now}, nil
+}
+
+// Close closes the database.
+func (m *Mirror) Close() error {
+	return m.DB.Close()
+}
+
+func (m *Mirror) now() time.Time {
+	if m.Now == nil {
+		return time.Now()
+	}
+
+	return m.Now()
+}
+
+// nullID stores unset IDs as NULL so they join cleanly.
+func nullID[T int | int64](id T) any {
+	if id == 0 {
+		return nil
+	}
+
+	return int64(id)
+}
+
+// nullTime stores zero times as NULL.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
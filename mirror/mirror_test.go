@@ -0,0 +1,251 @@
+package mirror
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/lunchmoneymock"
+	"github.com/icco/lunchmoney/lunchmoneytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI is an in-memory account that applies date ranges and paging to
+// transactions like the API does.
+type fakeAPI struct {
+	transactions []*lunchmoney.Transaction
+	categories   []*lunchmoney.Category
+	tags         []*lunchmoney.Tag
+	assets       []*lunchmoney.Asset
+	plaid        []*lunchmoney.PlaidAccount
+	budgets      []*lunchmoney.Budget
+	requests     int
+}
+
+// client returns a Client whose services read from f.
+func (f *fakeAPI) client() *lunchmoney.Client {
+	return &lunchmoney.Client{
+		Transactions: &lunchmoneymock.TransactionServiceMock{ListFunc: f.listTransactions},
+		Categories: &lunchmoneymock.CategoryServiceMock{ListFunc: func(context.Context) ([]*lunchmoney.Category, error) {
+			return f.categories, nil
+		}},
+		Tags: &lunchmoneymock.TagServiceMock{ListFunc: func(context.Context) ([]*lunchmoney.Tag, error) {
+			return f.tags, nil
+		}},
+		Assets: &lunchmoneymock.AssetServiceMock{ListFunc: func(context.Context) ([]*lunchmoney.Asset, error) {
+			return f.assets, nil
+		}},
+		Plaid: &lunchmoneymock.PlaidAccountServiceMock{ListFunc: func(context.Context) ([]*lunchmoney.PlaidAccount, error) {
+			return f.plaid, nil
+		}},
+		Budgets: &lunchmoneymock.BudgetServiceMock{ListFunc: f.listBudgets},
+	}
+}
+
+func (f *fakeAPI) listTransactions(_ context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+	f.requests++
+
+	var ret []*lunchmoney.Transaction
+	for _, t := range f.transactions {
+		if t.Date >= *filters.StartDate && t.Date <= *filters.EndDate {
+			ret = append(ret, t)
+		}
+	}
+
+	offset := min(int(*filters.Offset), len(ret))
+	end := min(offset+int(*filters.Limit), len(ret))
+
+	return ret[offset:end], nil
+}
+
+func (f *fakeAPI) listBudgets(_ context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	var ret []*lunchmoney.Budget
+	for _, b := range f.budgets {
+		one := *b
+		one.Data = map[string]*lunchmoney.BudgetData{}
+		for month, d := range b.Data {
+			if month >= filters.StartDate && month <= filters.EndDate {
+				one.Data[month] = d
+			}
+		}
+		ret = append(ret, &one)
+	}
+
+	return ret, nil
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{
+		transactions: []*lunchmoney.Transaction{
+			{ID: 1, Date: "2023-01-10", Payee: "Rent", Amount: "1500.0000", Currency: "usd", CategoryID: 11, UpdatedAt: "2023-01-10T00:00:00Z"},
+			{ID: 2, Date: "2023-03-01", Payee: "Blue Bottle", Amount: "4.5000", Currency: "usd", CategoryID: 10, AssetID: 5, UpdatedAt: "2023-03-01T00:00:00Z",
+				Tags: []lunchmoney.Tag{{ID: 7, Name: "work"}}},
+			{ID: 3, Date: "2023-03-10", Payee: "Payroll", Amount: "-2000.0000", Currency: "usd", ToBase: -2000, UpdatedAt: "2023-03-10T00:00:00Z"},
+		},
+		categories: []*lunchmoney.Category{{ID: 10, Name: "Coffee"}, {ID: 11, Name: "apartment"}},
+		tags:       []*lunchmoney.Tag{{ID: 7, Name: "work"}},
+		assets:     []*lunchmoney.Asset{{ID: 5, Name: "Checking", Balance: "100.0000", Currency: "usd"}},
+		plaid:      []*lunchmoney.PlaidAccount{{ID: 8, Name: "Card", Balance: "20.0000", Currency: "usd"}},
+		budgets: []*lunchmoney.Budget{{
+			CategoryID:   10,
+			CategoryName: "Coffee",
+			Data: map[string]*lunchmoney.BudgetData{
+				"2023-02-01": {BudgetMonth: "2023-02-01", BudgetAmount: "50", BudgetCurrency: "usd", SpendingToBase: 40},
+				"2023-03-01": {BudgetMonth: "2023-03-01", BudgetAmount: "50", BudgetCurrency: "usd", SpendingToBase: 4.5},
+			},
+		}},
+	}
+}
+
+func openTest(t *testing.T, now time.Time) *Mirror {
+	t.Helper()
+
+	m, err := Open(filepath.Join(t.TempDir(), "mirror.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, m.Close()) })
+	m.Now = func() time.Time { return now }
+
+	return m
+}
+
+func ids(txns []*lunchmoney.Transaction) []int64 {
+	ret := make([]int64, len(txns))
+	for i, t := range txns {
+		ret[i] = t.ID
+	}
+	sort.Slice(ret, func(i, k int) bool { return ret[i] < ret[k] })
+
+	return ret
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeAPI()
+	m := openTest(t, time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	report, err := m.Sync(ctx, api.client(), &SyncOptions{Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "2023-01-01", report.From)
+	assert.Equal(t, "2023-03-15", report.To)
+	assert.Equal(t, Stats{Inserted: 3}, report.Transactions)
+	assert.Equal(t, Stats{Inserted: 2}, report.Categories)
+	assert.Equal(t, Stats{Inserted: 2}, report.Budgets)
+	assert.Equal(t, 2, api.requests, "three transactions with a page size of two")
+
+	var total float64
+	require.NoError(t, m.DB.QueryRow("SELECT SUM(amount) FROM transactions").Scan(&total))
+	assert.Equal(t, -495.5, total)
+
+	// A week later: one edit, one deletion and one new transaction inside
+	// the window, and nothing re-fetched from January.
+	m.Now = func() time.Time { return time.Date(2023, 3, 22, 12, 0, 0, 0, time.UTC) }
+	api.transactions[1].Payee = "Blue Bottle Coffee"
+	api.transactions[1].UpdatedAt = "2023-03-16T00:00:00Z"
+	api.transactions = append(api.transactions[:2],
+		&lunchmoney.Transaction{ID: 4, Date: "2023-03-20", Payee: "Bookshop", Amount: "20.0000", Currency: "usd", UpdatedAt: "2023-03-20T00:00:00Z"})
+	api.categories = api.categories[:1]
+
+	report, err = m.Sync(ctx, api.client(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2023-02-13", report.From)
+	assert.Equal(t, Stats{Inserted: 1, Updated: 1, Deleted: 1}, report.Transactions)
+	assert.Equal(t, Stats{Unchanged: 1, Deleted: 1}, report.Categories)
+	assert.Equal(t, Stats{Unchanged: 2}, report.Budgets)
+
+	txns, err := m.Transactions().List(ctx, &lunchmoney.TransactionFilters{StartDate: ptr("2023-01-01"), EndDate: ptr("2023-03-31")})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 4}, ids(txns))
+
+	// A full sync revisits January too.
+	report, err = m.Sync(ctx, api.client(), &SyncOptions{Full: true})
+	require.NoError(t, err)
+	assert.Equal(t, "2023-01-01", report.From)
+	assert.Equal(t, Stats{Unchanged: 3}, report.Transactions)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestReader(t *testing.T) {
+	ctx := context.Background()
+	m := openTest(t, time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC))
+	_, err := m.Sync(ctx, newFakeAPI().client(), &SyncOptions{Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+
+	// The mirror stands in for the API.
+	client, err := lunchmoney.NewClient("unused")
+	require.NoError(t, err)
+	client.Transactions = m.Transactions()
+	client.Categories = m.Categories()
+	client.Assets = m.Assets()
+	client.Budgets = m.Budgets()
+
+	tests := []struct {
+		name    string
+		filters *lunchmoney.TransactionFilters
+		want    []int64
+	}{
+		{name: "current month by default", want: []int64{2, 3}},
+		{name: "date range", filters: &lunchmoney.TransactionFilters{StartDate: ptr("2023-01-01"), EndDate: ptr("2023-01-31")}, want: []int64{1}},
+		{name: "category", filters: &lunchmoney.TransactionFilters{StartDate: ptr("2023-01-01"), EndDate: ptr("2023-03-31"), CategoryID: ptr(int64(11))}, want: []int64{1}},
+		{name: "asset", filters: &lunchmoney.TransactionFilters{AssetID: ptr(int64(5))}, want: []int64{2}},
+		{name: "tag", filters: &lunchmoney.TransactionFilters{TagID: ptr(int64(7))}, want: []int64{2}},
+		{name: "paging", filters: &lunchmoney.TransactionFilters{Offset: ptr(int64(1)), Limit: ptr(int64(5))}, want: []int64{3}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			txns, err := client.Transactions.List(ctx, tc.filters)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ids(txns))
+		})
+	}
+
+	txn, err := client.Transactions.Get(ctx, 3, &lunchmoney.TransactionFilters{DebitAsNegative: ptr(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "2000.0000", txn.Amount)
+	assert.Equal(t, 2000.0, txn.ToBase)
+
+	_, err = client.Transactions.Get(ctx, 99, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	cats, err := client.Categories.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, cats, 2)
+	assert.Equal(t, "apartment", cats[0].Name)
+
+	_, err = client.Categories.Get(ctx, 99)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	budgets, err := client.Budgets.List(ctx, &lunchmoney.BudgetFilters{StartDate: "2023-02-01", EndDate: "2023-03-31"})
+	require.NoError(t, err)
+	require.Len(t, budgets, 1)
+	assert.Len(t, budgets[0].Data, 2)
+	assert.Equal(t, 40.0, budgets[0].Data["2023-02-01"].SpendingToBase)
+
+	assets, err := client.Assets.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "100.0000", assets[0].Balance)
+
+	notes := "offline"
+	_, err = client.Transactions.Update(ctx, 3, &lunchmoney.UpdateTransaction{Notes: &notes})
+	assert.ErrorIs(t, err, ErrReadOnly)
+}
+
+func TestSyncClientLiteral(t *testing.T) {
+	srv := lunchmoneytest.NewServer(nil)
+	defer srv.Close()
+	base := srv.Client()
+	client := &lunchmoney.Client{HTTP: base.HTTP, Base: base.Base}
+
+	m := openTest(t, time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC))
+	report, err := m.Sync(context.Background(), client, &SyncOptions{Start: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.Equal(t, len(srv.Data().Categories), report.Categories.Inserted)
+	assert.NotZero(t, report.Transactions.Inserted)
+}
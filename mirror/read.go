@@ -0,0 +1,308 @@
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/icco/lunchmoney"
+)
+
+// Transactions returns the mirror's transactions as a TransactionService,
+// so code written against the API can run offline:
+//
+//	client.Transactions = m.Transactions()
+//
+// Its writes return ErrReadOnly.
+func (m *Mirror) Transactions() lunchmoney.TransactionService { return &transactionService{m} }
+
+// Categories returns the mirror's categories as a CategoryService.
+func (m *Mirror) Categories() lunchmoney.CategoryService { return &categoryService{m} }
+
+// Tags returns the mirror's tags as a TagService.
+func (m *Mirror) Tags() lunchmoney.TagService { return &tagService{m} }
+
+// Assets returns the mirror's assets as an AssetService. Its Update returns
+// ErrReadOnly.
+func (m *Mirror) Assets() lunchmoney.AssetService { return &assetService{m} }
+
+// Plaid returns the mirror's Plaid accounts as a PlaidAccountService.
+func (m *Mirror) Plaid() lunchmoney.PlaidAccountService { return &plaidAccountService{m} }
+
+// Budgets returns the mirror's budgets as a BudgetService.
+func (m *Mirror) Budgets() lunchmoney.BudgetService { return &budgetService{m} }
+
+type transactionService struct{ m *Mirror }
+
+type categoryService struct{ m *Mirror }
+
+type tagService struct{ m *Mirror }
+
+type assetService struct{ m *Mirror }
+
+type plaidAccountService struct{ m *Mirror }
+
+type budgetService struct{ m *Mirror }
+
+var (
+	_ lunchmoney.TransactionService  = (*transactionService)(nil)
+	_ lunchmoney.CategoryService     = (*categoryService)(nil)
+	_ lunchmoney.TagService          = (*tagService)(nil)
+	_ lunchmoney.AssetService        = (*assetService)(nil)
+	_ lunchmoney.PlaidAccountService = (*plaidAccountService)(nil)
+	_ lunchmoney.BudgetService       = (*budgetService)(nil)
+)
+
+// List returns mirrored transactions matching filters, ordered
+// by date. As with the API, only the current month is returned when no date
+// range is given.
+func (s *transactionService) List(ctx context.Context, filters *lunchmoney.TransactionFilters) ([]*lunchmoney.Transaction, error) {
+	if filters == nil {
+		filters = &lunchmoney.TransactionFilters{}
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(filters); err != nil {
+		return nil, err
+	}
+
+	var where []string
+	var args []any
+	add := func(clause string, arg ...any) {
+		where = append(where, clause)
+		args = append(args, arg...)
+	}
+
+	switch {
+	case filters.StartDate != nil && filters.EndDate != nil:
+		add("date BETWEEN ? AND ?", *filters.StartDate, *filters.EndDate)
+	case filters.StartDate != nil || filters.EndDate != nil:
+		return nil, fmt.Errorf("start_date and end_date must be used together")
+	default:
+		now := s.m.now()
+		first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		add("date BETWEEN ? AND ?", first.Format(dateFormat), first.AddDate(0, 1, -1).Format(dateFormat))
+	}
+
+	for column, id := range map[string]*int64{
+		"category_id":      filters.CategoryID,
+		"asset_id":         filters.AssetID,
+		"plaid_account_id": filters.PlaidAccountID,
+		"recurring_id":     filters.RecurringID,
+	} {
+		if id != nil {
+			add(column+" = ?", *id)
+		}
+	}
+
+	if filters.TagID != nil {
+		add("EXISTS (SELECT 1 FROM json_each(raw, '$.tags') WHERE json_extract(value, '$.id') = ?)", *filters.TagID)
+	}
+
+	query := "SELECT raw FROM transactions WHERE " + strings.Join(where, " AND ") + " ORDER BY date, id"
+	if filters.Limit != nil || filters.Offset != nil {
+		limit, offset := int64(-1), int64(0)
+		if filters.Limit != nil {
+			limit = *filters.Limit
+		}
+		if filters.Offset != nil {
+			offset = *filters.Offset
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, offset)
+	}
+
+	txns, err := queryRaw[lunchmoney.Transaction](ctx, s.m.DB, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+
+	if filters.DebitAsNegative != nil && *filters.DebitAsNegative {
+		for _, t := range txns {
+			negate(t)
+		}
+	}
+
+	return txns, nil
+}
+
+// Get returns a single mirrored transaction, or ErrNotFound.
+func (s *transactionService) Get(ctx context.Context, id int64, filters *lunchmoney.TransactionFilters) (*lunchmoney.Transaction, error) {
+	txns, err := queryRaw[lunchmoney.Transaction](ctx, s.m.DB, "SELECT raw FROM transactions WHERE id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("get transaction %d: %w", id, err)
+	}
+	if len(txns) == 0 {
+		return nil, fmt.Errorf("get transaction %d: %w", id, ErrNotFound)
+	}
+
+	t := txns[0]
+	if filters != nil && filters.DebitAsNegative != nil && *filters.DebitAsNegative {
+		negate(t)
+	}
+
+	return t, nil
+}
+
+// Create returns ErrReadOnly.
+func (s *transactionService) Create(context.Context, lunchmoney.InsertTransactionsRequest) (*lunchmoney.InsertTransactionsResponse, error) {
+	return nil, ErrReadOnly
+}
+
+// Update returns ErrReadOnly.
+func (s *transactionService) Update(context.Context, int64, *lunchmoney.UpdateTransaction) (*lunchmoney.UpdateTransactionResp, error) {
+	return nil, ErrReadOnly
+}
+
+// UpdateWithOptions returns ErrReadOnly.
+func (s *transactionService) UpdateWithOptions(context.Context, int64, *lunchmoney.UpdateTransaction, *lunchmoney.UpdateTransactionOptions) (*lunchmoney.UpdateTransactionResp, error) {
+	return nil, ErrReadOnly
+}
+
+// BulkUpdate returns ErrReadOnly.
+func (s *transactionService) BulkUpdate(context.Context, map[int64]*lunchmoney.UpdateTransaction, *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error) {
+	return nil, ErrReadOnly
+}
+
+// List returns mirrored categories in alphabetical order.
+func (s *categoryService) List(ctx context.Context) ([]*lunchmoney.Category, error) {
+	cats, err := queryRaw[lunchmoney.Category](ctx, s.m.DB, "SELECT raw FROM categories ORDER BY name COLLATE NOCASE, id")
+	if err != nil {
+		return nil, fmt.Errorf("get categories: %w", err)
+	}
+
+	return cats, nil
+}
+
+// Get returns a single mirrored category, or ErrNotFound.
+func (s *categoryService) Get(ctx context.Context, id int64) (*lunchmoney.Category, error) {
+	cats, err := queryRaw[lunchmoney.Category](ctx, s.m.DB, "SELECT raw FROM categories WHERE id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("get category %d: %w", id, err)
+	}
+	if len(cats) == 0 {
+		return nil, fmt.Errorf("get category %d: %w", id, ErrNotFound)
+	}
+
+	return cats[0], nil
+}
+
+// List returns mirrored tags.
+func (s *tagService) List(ctx context.Context) ([]*lunchmoney.Tag, error) {
+	tags, err := queryRaw[lunchmoney.Tag](ctx, s.m.DB, "SELECT raw FROM tags ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// List returns mirrored assets.
+func (s *assetService) List(ctx context.Context) ([]*lunchmoney.Asset, error) {
+	assets, err := queryRaw[lunchmoney.Asset](ctx, s.m.DB, "SELECT raw FROM assets ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("get assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// Update returns ErrReadOnly.
+func (s *assetService) Update(context.Context, int64, *lunchmoney.UpdateAsset) (*lunchmoney.Asset, error) {
+	return nil, ErrReadOnly
+}
+
+// List returns mirrored Plaid accounts.
+func (s *plaidAccountService) List(ctx context.Context) ([]*lunchmoney.PlaidAccount, error) {
+	accounts, err := queryRaw[lunchmoney.PlaidAccount](ctx, s.m.DB, "SELECT raw FROM plaid_accounts ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("get plaid accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// List returns mirrored budgets for the months between the filter
+// dates, one Budget per category with a Data entry per month.
+func (s *budgetService) List(ctx context.Context, filters *lunchmoney.BudgetFilters) ([]*lunchmoney.Budget, error) {
+	if filters == nil {
+		return nil, fmt.Errorf("budget filters are required")
+	}
+
+	validate := validator.New()
+	if err := validate.StructCtx(ctx, filters); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(dateFormat, filters.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	first := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	months, err := queryRaw[lunchmoney.Budget](ctx, s.m.DB, "SELECT raw FROM budgets WHERE month BETWEEN ? AND ? ORDER BY category_id, month",
+		first.Format(dateFormat), filters.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("get budgets: %w", err)
+	}
+
+	var ret []*lunchmoney.Budget
+	byCategory := map[int]*lunchmoney.Budget{}
+	for _, b := range months {
+		if existing, ok := byCategory[b.CategoryID]; ok {
+			for month, d := range b.Data {
+				existing.Data[month] = d
+			}
+			continue
+		}
+
+		byCategory[b.CategoryID] = b
+		ret = append(ret, b)
+	}
+
+	return ret, nil
+}
+
+// queryRaw decodes the raw column of every row query returns.
+func queryRaw[T any](ctx context.Context, db *sql.DB, query string, args ...any) (ret []*T, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+
+		v := new(T)
+		if err := json.Unmarshal(raw, v); err != nil {
+			return nil, fmt.Errorf("decode %T: %w", v, err)
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, rows.Err()
+}
+
+// negate flips a transaction to the debit as negative convention, as the API
+// does when asked.
+func negate(t *lunchmoney.Transaction) {
+	switch {
+	case strings.HasPrefix(t.Amount, "-"):
+		t.Amount = strings.TrimPrefix(t.Amount, "-")
+	case t.Amount != "" && strings.Trim(t.Amount, "0.") != "":
+		t.Amount = "-" + t.Amount
+	}
+	t.ToBase = -t.ToBase
+}
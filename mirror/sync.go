@@ -0,0 +1,475 @@
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+const dateFormat = "2006-01-02"
+
+// SyncOptions control how much history Sync re-fetches.
+type SyncOptions struct {
+	// Start is the earliest transaction date to mirror. It defaults to a
+	// year before the first sync. Setting it earlier than a previous sync's
+	// start backfills the difference.
+	Start time.Time
+	// Window is how many days before the last sync are re-fetched, to pick
+	// up edits and deletions of recent transactions. Defaults to 30.
+	Window int
+	// Full re-fetches every transaction since Start, to pick up edits to
+	// older history.
+	Full bool
+	// PageSize is how many transactions are requested at a time. Defaults
+	// to 1000, the API's maximum.
+	PageSize int64
+}
+
+// Stats counts the changes Sync made to one table.
+type Stats struct {
+	Inserted  int
+	Updated   int
+	Deleted   int
+	Unchanged int
+}
+
+// SyncReport describes what a Sync did.
+type SyncReport struct {
+	// From and To are the transaction dates that were re-fetched.
+	From, To      string
+	Transactions  Stats
+	Categories    Stats
+	Tags          Stats
+	Assets        Stats
+	PlaidAccounts Stats
+	Budgets       Stats
+}
+
+// Sync brings the mirror up to date through client's services. Categories, tags, assets and Plaid
+// accounts are fetched in full. Transactions and budgets are fetched for the
+// recent window described by opts. Records are only rewritten when their
+// updated_at, or their contents for records without one, changed, and
+// records missing from the fetched range are deleted. Everything is written
+// in a single database transaction.
+func (m *Mirror) Sync(ctx context.Context, client *lunchmoney.Client, opts *SyncOptions) (*SyncReport, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	window := opts.Window
+	if window <= 0 {
+		window = 30
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 1000
+	}
+
+	now := m.now()
+	state, err := m.state(ctx, "transactions")
+	if err != nil {
+		return nil, err
+	}
+
+	start := now.AddDate(-1, 0, 0)
+	if state != nil {
+		start = state.start
+	}
+	backfill := !opts.Start.IsZero() && opts.Start.Before(start)
+	if backfill || (state == nil && !opts.Start.IsZero()) {
+		start = opts.Start
+	}
+
+	from := start
+	if state != nil && !opts.Full && !backfill {
+		if recent := state.syncedAt.AddDate(0, 0, -window); recent.After(from) {
+			from = recent
+		}
+	}
+
+	report := &SyncReport{From: from.Format(dateFormat), To: now.Format(dateFormat)}
+
+	categories, err := client.CategoryService().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch categories: %w", err)
+	}
+
+	tags, err := client.TagService().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch tags: %w", err)
+	}
+
+	assets, err := client.AssetService().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch assets: %w", err)
+	}
+
+	plaid, err := client.PlaidAccountService().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch plaid accounts: %w", err)
+	}
+
+	var txns []*lunchmoney.Transaction
+	for offset := int64(0); ; {
+		page, err := client.TransactionService().List(ctx, &lunchmoney.TransactionFilters{
+			StartDate: &report.From,
+			EndDate:   &report.To,
+			Offset:    &offset,
+			Limit:     &pageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fetch transactions from offset %d: %w", offset, err)
+		}
+
+		txns = append(txns, page...)
+		if int64(len(page)) < pageSize {
+			break
+		}
+		offset += int64(len(page))
+	}
+
+	firstMonth := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	budgets, err := client.BudgetService().List(ctx, &lunchmoney.BudgetFilters{
+		StartDate: firstMonth.Format(dateFormat),
+		EndDate:   lastMonth.AddDate(0, 1, -1).Format(dateFormat),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch budgets: %w", err)
+	}
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	steps := []struct {
+		name  string
+		stats *Stats
+		table table
+		rows  func() ([]row, error)
+		scope string
+		args  []any
+	}{
+		{"categories", &report.Categories, categoriesTable, func() ([]row, error) { return rowsOf(categories, categoryRow) }, "", nil},
+		{"tags", &report.Tags, tagsTable, func() ([]row, error) { return rowsOf(tags, tagRow) }, "", nil},
+		{"assets", &report.Assets, assetsTable, func() ([]row, error) { return rowsOf(assets, assetRow) }, "", nil},
+		{"plaid accounts", &report.PlaidAccounts, plaidTable, func() ([]row, error) { return rowsOf(plaid, plaidRow) }, "", nil},
+		{"transactions", &report.Transactions, transactionsTable, func() ([]row, error) { return rowsOf(txns, transactionRow) },
+			"date BETWEEN ? AND ?", []any{report.From, report.To}},
+		{"budgets", &report.Budgets, budgetsTable, func() ([]row, error) { return budgetRows(budgets) },
+			"month BETWEEN ? AND ?", []any{firstMonth.Format(dateFormat), lastMonth.Format(dateFormat)}},
+	}
+	for _, s := range steps {
+		rows, err := s.rows()
+		if err != nil {
+			return nil, fmt.Errorf("sync %s: %w", s.name, err)
+		}
+
+		if *s.stats, err = syncTable(ctx, tx, s.table, rows, s.scope, s.args...); err != nil {
+			return nil, fmt.Errorf("sync %s: %w", s.name, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO sync_state (resource, start_date, synced_at) VALUES (?, ?, ?)
+		ON CONFLICT (resource) DO UPDATE SET start_date = excluded.start_date, synced_at = excluded.synced_at`,
+		"transactions", start.Format(dateFormat), now.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("save sync state: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return report, nil
+}
+
+type syncState struct {
+	start    time.Time
+	syncedAt time.Time
+}
+
+// state returns when resource was last synced, or nil if it never was.
+func (m *Mirror) state(ctx context.Context, resource string) (*syncState, error) {
+	var start, syncedAt string
+	err := m.DB.QueryRowContext(ctx, "SELECT start_date, synced_at FROM sync_state WHERE resource = ?", resource).Scan(&start, &syncedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load sync state: %w", err)
+	}
+
+	s := &syncState{}
+	if s.start, err = time.Parse(dateFormat, start); err != nil {
+		return nil, fmt.Errorf("parse sync start: %w", err)
+	}
+	if s.syncedAt, err = time.Parse(time.RFC3339, syncedAt); err != nil {
+		return nil, fmt.Errorf("parse sync time: %w", err)
+	}
+
+	return s, nil
+}
+
+// table describes a mirrored table. The first keys columns are its primary
+// key, and version is an SQL expression compared with row.version to decide
+// whether a row changed.
+type table struct {
+	name    string
+	columns []string
+	keys    int
+	version string
+}
+
+// row is a record ready to be written, with values in table column order.
+type row struct {
+	version string
+	values  []any
+}
+
+func (r row) key(t table) string {
+	return keyString(r.values[:t.keys])
+}
+
+func keyString(key []any) string {
+	parts := make([]string, len(key))
+	for i, k := range key {
+		parts[i] = fmt.Sprint(k)
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// syncTable makes the rows of t matching scope equal to rows, and reports
+// what changed. An empty scope covers the whole table.
+func syncTable(ctx context.Context, tx *sql.Tx, t table, rows []row, scope string, args ...any) (Stats, error) {
+	var stats Stats
+	keyCols := strings.Join(t.columns[:t.keys], ", ")
+
+	query := fmt.Sprintf("SELECT %s, %s FROM %s", keyCols, t.version, t.name)
+	if scope != "" {
+		query += " WHERE " + scope
+	}
+
+	existing := map[string][]any{}
+	versions := map[string]string{}
+	rs, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return stats, err
+	}
+	for rs.Next() {
+		key := make([]any, t.keys)
+		dest := make([]any, t.keys+1)
+		for i := range key {
+			dest[i] = &key[i]
+		}
+		var version sql.NullString
+		dest[t.keys] = &version
+
+		if err := rs.Scan(dest...); err != nil {
+			_ = rs.Close()
+			return stats, err
+		}
+		k := keyString(key)
+		existing[k] = key
+		versions[k] = version.String
+	}
+	if err := rs.Close(); err != nil {
+		return stats, err
+	}
+	if err := rs.Err(); err != nil {
+		return stats, err
+	}
+
+	updates := make([]string, 0, len(t.columns))
+	for _, c := range t.columns[t.keys:] {
+		updates = append(updates, fmt.Sprintf("%s = excluded.%s", c, c))
+	}
+	upsert, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+		t.name, strings.Join(t.columns, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(t.columns)), ", "), keyCols, strings.Join(updates, ", ")))
+	if err != nil {
+		return stats, err
+	}
+	defer func() {
+		_ = upsert.Close()
+	}()
+
+	seen := map[string]bool{}
+	for _, r := range rows {
+		k := r.key(t)
+		seen[k] = true
+
+		v, ok := versions[k]
+		switch {
+		case !ok:
+			stats.Inserted++
+		case v != r.version:
+			stats.Updated++
+		default:
+			stats.Unchanged++
+			continue
+		}
+
+		if _, err := upsert.ExecContext(ctx, r.values...); err != nil {
+			return stats, fmt.Errorf("write %s: %w", k, err)
+		}
+	}
+
+	where := make([]string, t.keys)
+	for i, c := range t.columns[:t.keys] {
+		where[i] = c + " = ?"
+	}
+	for k, key := range existing {
+		if seen[k] {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", t.name, strings.Join(where, " AND ")), key...); err != nil {
+			return stats, fmt.Errorf("delete %s: %w", k, err)
+		}
+		stats.Deleted++
+	}
+
+	return stats, nil
+}
+
+var transactionsTable = table{
+	name: "transactions",
+	columns: []string{"id", "date", "payee", "original_name", "amount", "currency", "to_base", "category_id", "category_name",
+		"category_group_id", "category_group_name", "is_income", "exclude_from_budget", "exclude_from_totals", "notes", "status",
+		"is_pending", "asset_id", "plaid_account_id", "recurring_id", "parent_id", "group_id", "is_group", "external_id", "tags",
+		"created_at", "updated_at", "raw"},
+	keys:    1,
+	version: "COALESCE(NULLIF(updated_at, ''), raw)",
+}
+
+func transactionRow(t *lunchmoney.Transaction, raw string) row {
+	tags := make([]string, len(t.Tags))
+	for i, tag := range t.Tags {
+		tags[i] = tag.Name
+	}
+
+	version := t.UpdatedAt
+	if version == "" {
+		version = raw
+	}
+
+	return row{version: version, values: []any{
+		t.ID, t.Date, t.Payee, t.OriginalName, t.Amount, t.Currency, t.ToBase, nullID(t.CategoryID), t.CategoryName,
+		nullID(t.CategoryGroupID), t.CategoryGroupName, t.IsIncome, t.ExcludeFromBudget, t.ExcludeFromTotals, t.Notes, t.Status,
+		t.IsPending, nullID(t.AssetID), nullID(t.PlaidAccountID), nullID(t.RecurringID), nullID(t.ParentID), nullID(t.GroupID),
+		t.IsGroup, t.ExternalID, strings.Join(tags, ","), t.CreatedAt, t.UpdatedAt, raw,
+	}}
+}
+
+var categoriesTable = table{
+	name:    "categories",
+	columns: []string{"id", "name", "description", "is_income", "exclude_from_budget", "exclude_from_totals", "is_group", "group_id", "created_at", "updated_at", "raw"},
+	keys:    1,
+	version: "raw",
+}
+
+func categoryRow(c *lunchmoney.Category, raw string) row {
+	return row{version: raw, values: []any{
+		c.ID, c.Name, c.Description, c.IsIncome, c.ExcludeFromBudget, c.ExcludeFromTotals, c.IsGroup, nullID(c.GroupID),
+		nullTime(c.CreatedAt), nullTime(c.UpdatedAt), raw,
+	}}
+}
+
+var tagsTable = table{
+	name:    "tags",
+	columns: []string{"id", "name", "description", "raw"},
+	keys:    1,
+	version: "raw",
+}
+
+func tagRow(t *lunchmoney.Tag, raw string) row {
+	return row{version: raw, values: []any{int64(t.ID), t.Name, t.Description, raw}}
+}
+
+var assetsTable = table{
+	name:    "assets",
+	columns: []string{"id", "type_name", "subtype_name", "name", "display_name", "balance", "balance_as_of", "to_base", "currency", "status", "institution_name", "created_at", "raw"},
+	keys:    1,
+	version: "raw",
+}
+
+func assetRow(a *lunchmoney.Asset, raw string) row {
+	return row{version: raw, values: []any{
+		a.ID, a.TypeName, a.SubtypeName, a.Name, a.DisplayName, a.Balance, nullTime(a.BalanceAsOf), a.ToBase, a.Currency,
+		a.Status, a.InstitutionName, nullTime(a.CreatedAt), raw,
+	}}
+}
+
+var plaidTable = table{
+	name:    "plaid_accounts",
+	columns: []string{"id", "name", "display_name", "type", "subtype", "mask", "institution_name", "status", "balance", "to_base", "currency", "balance_last_update", "last_import", "raw"},
+	keys:    1,
+	version: "raw",
+}
+
+func plaidRow(p *lunchmoney.PlaidAccount, raw string) row {
+	return row{version: raw, values: []any{
+		p.ID, p.Name, p.DisplayName, p.Type, p.Subtype, p.Mask, p.InstitutionName, p.Status, p.Balance, p.ToBase, p.Currency,
+		nullTime(p.BalanceLastUpdate), nullTime(p.LastImport), raw,
+	}}
+}
+
+var budgetsTable = table{
+	name: "budgets",
+	columns: []string{"category_id", "month", "category_name", "category_group_name", "group_id", "is_group", "is_income",
+		"budget_amount", "budget_currency", "budget_to_base", "spending_to_base", "num_transactions", "raw"},
+	keys:    2,
+	version: "raw",
+}
+
+// budgetRows splits budgets into a row per category and month. Each row's
+// raw column holds the budget with only that month's data.
+func budgetRows(budgets []*lunchmoney.Budget) ([]row, error) {
+	var rows []row
+	for _, b := range budgets {
+		for month, d := range b.Data {
+			one := *b
+			one.Data = map[string]*lunchmoney.BudgetData{month: d}
+			raw, err := json.Marshal(&one)
+			if err != nil {
+				return nil, fmt.Errorf("encode budget %d %s: %w", b.CategoryID, month, err)
+			}
+
+			var amount any
+			if d.BudgetAmount != "" {
+				amount = d.BudgetAmount.String()
+			}
+
+			rows = append(rows, row{version: string(raw), values: []any{
+				int64(b.CategoryID), month, b.CategoryName, b.CategoryGroupName, nullID(b.GroupID), b.IsGroup, b.IsIncome,
+				amount, d.BudgetCurrency, d.BudgetToBase, d.SpendingToBase, d.NumTransactions, string(raw),
+			}})
+		}
+	}
+
+	return rows, nil
+}
+
+// rowsOf encodes each item's raw JSON and builds its row with f.
+func rowsOf[T any](items []T, f func(item T, raw string) row) ([]row, error) {
+	ret := make([]row, 0, len(items))
+	for _, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("encode %T: %w", item, err)
+		}
+		ret = append(ret, f(item, string(b)))
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,294 @@
+package lunchmoney
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+// Account sources used in NetWorthAccount.Source.
+const (
+	SourceAsset  = "asset"
+	SourcePlaid  = "plaid"
+	SourceCrypto = "crypto"
+)
+
+// liabilityTypes are the asset and Plaid account types whose balances are
+// owed rather than owned.
+var liabilityTypes = map[string]bool{
+	"credit":          true,
+	"loan":            true,
+	"other liability": true,
+}
+
+// liabilitySubtypes are subtypes that are owed even when the account's type
+// is not a liability type, such as a "cash" asset with a "line of credit"
+// subtype.
+var liabilitySubtypes = map[string]bool{
+	"credit":         true,
+	"credit card":    true,
+	"line of credit": true,
+	"loan":           true,
+	"mortgage":       true,
+	"student loan":   true,
+	"auto loan":      true,
+	"personal loan":  true,
+	"home equity":    true,
+}
+
+// staleStatuses are account statuses whose balances may be stale.
+var staleStatuses = map[string]bool{
+	"closed":      true,
+	"inactive":    true,
+	"deactivated": true,
+}
+
+// IsLiability reports whether an asset or Plaid account of type typ and
+// subtype subtype is owed rather than owned, such as a credit card or a loan.
+func IsLiability(typ, subtype string) bool {
+	return liabilityTypes[strings.ToLower(typ)] || liabilitySubtypes[strings.ToLower(subtype)]
+}
+
+// IsStale reports whether an account with status, such as a closed one, may
+// have a stale balance. NetWorth leaves these accounts out.
+func IsStale(status string) bool {
+	return staleStatuses[status]
+}
+
+// NetWorthAccount is a single account's contribution to net worth.
+type NetWorthAccount struct {
+	Source      string
+	ID          int64
+	Name        string
+	Type        string
+	Subtype     string
+	Institution string
+	Liability   bool
+	Balance     *money.Money
+	// Base is the balance in the primary currency, nil if it couldn't be
+	// converted.
+	Base        *money.Money
+	BalanceAsOf time.Time
+}
+
+// Contribution is the signed amount this account adds to net worth in the
+// primary currency: liabilities are negated.
+func (a *NetWorthAccount) Contribution() *money.Money {
+	if a.Base == nil {
+		return nil
+	}
+
+	if a.Liability {
+		return a.Base.Negative()
+	}
+
+	return a.Base
+}
+
+// NetWorthReport is a snapshot of everything the user owns and owes, in
+// their primary currency.
+type NetWorthReport struct {
+	Currency    string
+	Assets      *money.Money
+	Liabilities *money.Money
+	NetWorth    *money.Money
+	// ByType and ByInstitution hold the signed contribution of each account
+	// type and institution to NetWorth.
+	ByType        map[string]*money.Money
+	ByInstitution map[string]*money.Money
+	Accounts      []*NetWorthAccount
+	// Unconverted accounts had no exchange rate to the primary currency and
+	// are left out of the totals. Crypto balances usually end up here.
+	Unconverted []*NetWorthAccount
+}
+
+// NetWorth fetches the user, assets, Plaid accounts and crypto balances
+// concurrently and combines them into a NetWorthReport.
+func (c *Client) NetWorth(ctx context.Context) (*NetWorthReport, error) {
+	var (
+		wg     sync.WaitGroup
+		user   *User
+		assets []*Asset
+		plaid  []*PlaidAccount
+		crypto []*Crypto
+		errs   = make([]error, 4)
+	)
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		user, errs[0] = c.UserService().Get(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		assets, errs[1] = c.AssetService().List(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		plaid, errs[2] = c.PlaidAccountService().List(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		crypto, errs[3] = c.CryptoService().List(ctx)
+	}()
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("net worth: %w", err)
+	}
+
+	cv, err := NewConverter(user.PrimaryCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNetWorthReport(cv, assets, plaid, crypto)
+}
+
+// NewNetWorthReport builds a NetWorthReport from already fetched accounts.
+// Closed and inactive accounts are skipped.
+func NewNetWorthReport(cv *Converter, assets []*Asset, plaid []*PlaidAccount, crypto []*Crypto) (*NetWorthReport, error) {
+	var accounts []*NetWorthAccount
+	var unconverted []*NetWorthAccount
+
+	add := func(a *NetWorthAccount, amount, currency string, toBase float64) error {
+		conv, err := cv.Convert(amount, currency, toBase)
+		switch {
+		case errors.Is(err, ErrNoExchangeRate):
+			f, _ := strconv.ParseFloat(amount, 64)
+			a.Balance = newMoney(f, currency)
+			unconverted = append(unconverted, a)
+			return nil
+		case err != nil:
+			return fmt.Errorf("%s %d: %w", a.Source, a.ID, err)
+		}
+
+		a.Balance = conv.Original
+		a.Base = conv.Base
+		accounts = append(accounts, a)
+		return nil
+	}
+
+	for _, a := range assets {
+		if IsStale(a.Status) {
+			continue
+		}
+
+		err := add(&NetWorthAccount{
+			Source:      SourceAsset,
+			ID:          a.ID,
+			Name:        cmp.Or(a.DisplayName, a.Name),
+			Type:        a.TypeName,
+			Subtype:     a.SubtypeName,
+			Institution: a.InstitutionName,
+			Liability:   IsLiability(a.TypeName, a.SubtypeName),
+			BalanceAsOf: a.BalanceAsOf,
+		}, a.Balance, a.Currency, a.ToBase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, p := range plaid {
+		if IsStale(p.Status) {
+			continue
+		}
+
+		err := add(&NetWorthAccount{
+			Source:      SourcePlaid,
+			ID:          p.ID,
+			Name:        cmp.Or(p.DisplayName, p.Name),
+			Type:        p.Type,
+			Subtype:     p.Subtype,
+			Institution: p.InstitutionName,
+			Liability:   IsLiability(p.Type, p.Subtype),
+			BalanceAsOf: p.BalanceLastUpdate,
+		}, p.Balance, p.Currency, p.ToBase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, cr := range crypto {
+		if IsStale(cr.Status) {
+			continue
+		}
+
+		err := add(&NetWorthAccount{
+			Source:      SourceCrypto,
+			ID:          cr.ID,
+			Name:        cmp.Or(cr.DisplayName, cr.Name),
+			Type:        SourceCrypto,
+			Institution: cr.InstitutionName,
+			BalanceAsOf: cr.BalanceAsOf,
+		}, cr.Balance, cr.Currency, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ret := &NetWorthReport{
+		Currency:      cv.Primary,
+		Assets:        money.New(0, cv.Primary),
+		Liabilities:   money.New(0, cv.Primary),
+		ByType:        map[string]*money.Money{},
+		ByInstitution: map[string]*money.Money{},
+		Accounts:      accounts,
+		Unconverted:   unconverted,
+	}
+
+	var err error
+	for _, a := range accounts {
+		if a.Liability {
+			ret.Liabilities, err = Sum(ret.Liabilities, a.Base)
+		} else {
+			ret.Assets, err = Sum(ret.Assets, a.Base)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := addTo(ret.ByType, a.Type, a.Contribution()); err != nil {
+			return nil, err
+		}
+
+		if err := addTo(ret.ByInstitution, a.Institution, a.Contribution()); err != nil {
+			return nil, err
+		}
+	}
+
+	ret.NetWorth, err = ret.Assets.Subtract(ret.Liabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ret.Accounts, func(i, j int) bool {
+		return strings.ToLower(ret.Accounts[i].Name) < strings.ToLower(ret.Accounts[j].Name)
+	})
+
+	return ret, nil
+}
+
+func addTo(totals map[string]*money.Money, key string, m *money.Money) error {
+	cur, ok := totals[key]
+	if !ok {
+		totals[key] = m
+		return nil
+	}
+
+	sum, err := Sum(cur, m)
+	if err != nil {
+		return err
+	}
+	totals[key] = sum
+
+	return nil
+}
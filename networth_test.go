@@ -0,0 +1,120 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetWorth(t *testing.T) {
+	fixtures := map[string]string{
+		"/v1/assets":         "lunchmoneytest/testdata/assets.json",
+		"/v1/plaid_accounts": "lunchmoneytest/testdata/plaid.json",
+		"/v1/crypto":         "lunchmoneytest/testdata/crypto.json",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/me" {
+			_, err := w.Write([]byte(`{"user_name": "Test", "primary_currency": "usd"}`))
+			require.NoError(t, err)
+			return
+		}
+
+		f, ok := fixtures[r.URL.Path]
+		require.True(t, ok, "unexpected path %s", r.URL.Path)
+		http.ServeFile(w, r, f)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+
+	got, err := client.NetWorth(context.Background())
+	require.NoError(t, err)
+
+	// Only USD and zero balances can be converted since the fixtures have no
+	// to_base, and the inactive 401k is skipped.
+	assert.Equal(t, "usd", got.Currency)
+	assert.Len(t, got.Accounts, 3)
+	assert.Len(t, got.Unconverted, 4)
+	assert.True(t, got.NetWorth.IsZero())
+	assert.Contains(t, got.ByInstitution, "Chase")
+	assert.Contains(t, got.ByType, "credit")
+	for _, a := range got.Accounts {
+		assert.Equal(t, a.Source != SourceCrypto, a.Liability, "%s liability", a.Name)
+	}
+}
+
+func TestNetWorthTotals(t *testing.T) {
+	responses := map[string]string{
+		"/v1/me": `{"user_name": "Test", "primary_currency": "cad"}`,
+		"/v1/assets": `{"assets": [
+			{"id": 1, "name": "Savings", "type_name": "cash", "balance": "1000.00", "currency": "usd", "to_base": 1350.5, "institution_name": "Tangerine", "status": "active"},
+			{"id": 2, "name": "Car loan", "type_name": "loan", "balance": "5000.00", "currency": "cad", "to_base": 5000, "institution_name": "RBC", "status": "active"},
+			{"id": 3, "name": "Old card", "type_name": "credit", "balance": "900.00", "currency": "cad", "to_base": 900, "institution_name": "RBC", "status": "closed"},
+			{"id": 7, "name": "HELOC", "type_name": "cash", "subtype_name": "line of credit", "balance": "300.00", "currency": "cad", "to_base": 300, "institution_name": "Tangerine", "status": "active"}
+		]}`,
+		"/v1/plaid_accounts": `{"plaid_accounts": [
+			{"id": 4, "name": "Visa", "type": "credit", "balance": "250.25", "currency": "usd", "to_base": 340.1, "institution_name": "RBC", "status": "active"},
+			{"id": 5, "name": "Chequing", "type": "depository", "balance": "2000.00", "currency": "cad", "to_base": 2000, "institution_name": "RBC", "status": "active"}
+		]}`,
+		"/v1/crypto": `{"crypto": [{"id": 6, "name": "Bitcoin", "balance": "0.5", "currency": "btc", "status": "active"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.URL.Path]
+		require.True(t, ok, "unexpected path %s", r.URL.Path)
+		_, err := w.Write([]byte(resp))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-token")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+
+	got, err := client.NetWorth(context.Background())
+	require.NoError(t, err)
+
+	cents := func(m map[string]*money.Money) map[string]int64 {
+		ret := map[string]int64{}
+		for k, v := range m {
+			assert.Equal(t, "CAD", v.Currency().Code, k)
+			ret[k] = v.Amount()
+		}
+		return ret
+	}
+
+	// Savings and Chequing are owned; the car loan, the Visa and the cash
+	// typed line of credit are owed. The closed card is skipped and the
+	// bitcoin has no exchange rate.
+	assert.Equal(t, map[string]int64{"assets": 335050, "liabilities": 564010, "net worth": -228960},
+		cents(map[string]*money.Money{"assets": got.Assets, "liabilities": got.Liabilities, "net worth": got.NetWorth}))
+	assert.Equal(t, map[string]int64{"cash": 105050, "loan": -500000, "credit": -34010, "depository": 200000}, cents(got.ByType))
+	assert.Equal(t, map[string]int64{"Tangerine": 105050, "RBC": -334010}, cents(got.ByInstitution))
+	assert.Len(t, got.Accounts, 5)
+	require.Len(t, got.Unconverted, 1)
+	assert.Equal(t, "Bitcoin", got.Unconverted[0].Name)
+	assert.Equal(t, int64(25025), got.Accounts[4].Balance.Amount(), "Visa keeps its original balance")
+	assert.Equal(t, "USD", got.Accounts[4].Balance.Currency().Code)
+}
+
+func TestAccountClassifiers(t *testing.T) {
+	assert.True(t, IsLiability("credit", ""))
+	assert.True(t, IsLiability("other liability", ""))
+	assert.True(t, IsLiability("cash", "line of credit"), "the subtype can make an account a liability")
+	assert.True(t, IsLiability("other asset", "Loan"))
+	assert.False(t, IsLiability("depository", "checking"))
+	assert.False(t, IsLiability("", ""))
+
+	assert.True(t, IsStale("closed"))
+	assert.False(t, IsStale("active"))
+	assert.False(t, IsStale(""))
+}
@@ -0,0 +1,111 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Nullable is a field in an update request that can be left unset, set to a
+// value, or cleared by sending an explicit null. The zero value is unset and
+// is dropped from the request by the omitzero tag option.
+type Nullable[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Set returns a Nullable holding v.
+func Set[T any](v T) Nullable[T] {
+	return Nullable[T]{value: v, set: true}
+}
+
+// Clear returns a Nullable that is sent as null, clearing the field.
+func Clear[T any]() Nullable[T] {
+	return Nullable[T]{set: true, null: true}
+}
+
+// IsZero reports whether the field is unset.
+func (n Nullable[T]) IsZero() bool {
+	return !n.set
+}
+
+// IsNull reports whether the field will be cleared.
+func (n Nullable[T]) IsNull() bool {
+	return n.set && n.null
+}
+
+// Get returns the value and whether one is set. Cleared and unset fields
+// return false.
+func (n Nullable[T]) Get() (T, bool) {
+	return n.value, n.set && !n.null
+}
+
+// MarshalJSON sends null for cleared fields and the value otherwise.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.set || n.null {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(n.value)
+}
+
+// UnmarshalJSON treats null as cleared and anything else as set.
+func (n *Nullable[T]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		*n = Clear[T]()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*n = Set(v)
+
+	return nil
+}
+
+// TagRef references a tag in an update request, either by ID or by name.
+// Lunch Money creates tags referenced by a name that doesn't exist yet.
+type TagRef struct {
+	ID   int64
+	Name string
+}
+
+// TagByID references an existing tag by its ID.
+func TagByID(id int64) TagRef {
+	return TagRef{ID: id}
+}
+
+// TagByName references a tag by name, creating it if needed.
+func TagByName(name string) TagRef {
+	return TagRef{Name: name}
+}
+
+// MarshalJSON sends the name as a string if set, otherwise the ID as a number.
+func (t TagRef) MarshalJSON() ([]byte, error) {
+	if t.Name != "" {
+		return json.Marshal(t.Name)
+	}
+
+	return []byte(strconv.FormatInt(t.ID, 10)), nil
+}
+
+// UnmarshalJSON accepts either a tag ID or a tag name.
+func (t *TagRef) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*t = TagByName(name)
+		return nil
+	}
+
+	var id int64
+	if err := json.Unmarshal(b, &id); err != nil {
+		return fmt.Errorf("tag must be an id or a name: %w", err)
+	}
+	*t = TagByID(id)
+
+	return nil
+}
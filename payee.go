@@ -0,0 +1,192 @@
+package lunchmoney
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// processorPrefix matches card processor and wallet prefixes like
+	// "SQ *", "TST* " and "PAYPAL *".
+	processorPrefix = regexp.MustCompile(`(?i)^(sq|tst|sp|pp|paypal|py|google|gglpay|apl|dd|ckc|fs|in|bt)\s*\*\s*`)
+	// bankPrefix matches card transaction verbs banks put before the merchant.
+	bankPrefix = regexp.MustCompile(`(?i)^(pos|checkcard|debit card|recurring|purchase)(\s+(debit|purchase|payment))?\s+`)
+	// storeNumber matches a store or terminal number and everything after it,
+	// which is usually a location.
+	storeNumber = regexp.MustCompile(`(?i)\s+(#\s*|no\.?\s*|store\s+)?\d{3,}\b.*$`)
+	// usLocation matches a trailing US state code, optionally with a ZIP code.
+	usLocation = regexp.MustCompile(`\s+(A[KLRZ]|C[AOT]|D[CE]|FL|GA|HI|I[ADLN]|K[SY]|LA|M[ADEINOST]|N[CDEHJMVY]|O[HKR]|PA|RI|S[CD]|T[NX]|UT|V[AT]|W[AIVY])(\s+\d{5}(-\d{4})?)?$`)
+	// phone matches phone numbers often appended to online merchants.
+	phone        = regexp.MustCompile(`\s+\+?\d?[\s-]?\(?\d{3}\)?[\s.-]?\d{3}[\s.-]?\d{4}\b`)
+	multiSpace   = regexp.MustCompile(`\s{2,}`)
+	trailingJunk = regexp.MustCompile(`[\s*#.,:;-]+$`)
+)
+
+// PayeeAlias maps every raw payee matching Pattern to Name.
+type PayeeAlias struct {
+	Pattern *regexp.Regexp
+	Name    string
+}
+
+// PayeeNormalizer turns raw bank descriptions like "SQ *BLUE BOTTLE 1234"
+// into canonical merchant names like "Blue Bottle". Built-in cleanup strips
+// processor prefixes, store numbers, phone numbers and trailing locations;
+// aliases then map cleaned names, or patterns over the raw name, to the name
+// you want to see.
+type PayeeNormalizer struct {
+	// Aliases maps a lowercased cleaned payee to its canonical name.
+	Aliases map[string]string
+	// Patterns are checked against the raw payee, in order, before Aliases.
+	Patterns []PayeeAlias
+	// RenameEdited lets PayeeUpdates rename transactions whose payee no
+	// longer matches their OriginalName, which usually means someone renamed
+	// them by hand. Off by default.
+	RenameEdited bool
+}
+
+// NewPayeeNormalizer returns a normalizer with no aliases.
+func NewPayeeNormalizer() *PayeeNormalizer {
+	return &PayeeNormalizer{Aliases: map[string]string{}}
+}
+
+// AddAlias maps payees that clean up to match (case insensitive) to name.
+func (n *PayeeNormalizer) AddAlias(match, name string) {
+	if n.Aliases == nil {
+		n.Aliases = map[string]string{}
+	}
+	n.Aliases[strings.ToLower(CleanPayee(match))] = name
+}
+
+// AddPattern maps raw payees matching the regular expression to name.
+func (n *PayeeNormalizer) AddPattern(pattern, name string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("alias %q: %w", name, err)
+	}
+	n.Patterns = append(n.Patterns, PayeeAlias{Pattern: re, Name: name})
+
+	return nil
+}
+
+// LoadAliases reads an alias table in JSON. "aliases" maps cleaned payees to
+// canonical names, and "patterns" is an ordered list of regular expressions:
+//
+//	{
+//	  "aliases": {"amzn mktp us": "Amazon"},
+//	  "patterns": [{"pattern": "(?i)^uber\\s*\\*?\\s*eats", "name": "Uber Eats"}]
+//	}
+func (n *PayeeNormalizer) LoadAliases(r io.Reader) error {
+	var table struct {
+		Aliases  map[string]string `json:"aliases"`
+		Patterns []struct {
+			Pattern string `json:"pattern"`
+			Name    string `json:"name"`
+		} `json:"patterns"`
+	}
+	if err := json.NewDecoder(r).Decode(&table); err != nil {
+		return fmt.Errorf("decode aliases: %w", err)
+	}
+
+	for match, name := range table.Aliases {
+		n.AddAlias(match, name)
+	}
+
+	for _, p := range table.Patterns {
+		if err := n.AddPattern(p.Pattern, p.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Normalize returns the canonical name for a raw payee.
+func (n *PayeeNormalizer) Normalize(raw string) string {
+	for _, p := range n.Patterns {
+		if p.Pattern.MatchString(raw) {
+			return p.Name
+		}
+	}
+
+	cleaned := CleanPayee(raw)
+	if name, ok := n.Aliases[strings.ToLower(cleaned)]; ok {
+		return name
+	}
+
+	return cleaned
+}
+
+// Transaction returns the canonical name for a transaction, preferring the
+// raw OriginalName from the bank and falling back to Payee and DisplayName.
+func (n *PayeeNormalizer) Transaction(t *Transaction) string {
+	return n.Normalize(cmp.Or(t.OriginalName, t.Payee, t.DisplayName))
+}
+
+// PayeeUpdates returns updates renaming every transaction whose payee differs
+// from its canonical name, ready for TransactionService.BulkUpdate.
+// Transactions with a payee other than their OriginalName are left alone
+// unless RenameEdited is set.
+func (n *PayeeNormalizer) PayeeUpdates(txns []*Transaction) map[int64]*UpdateTransaction {
+	ret := map[int64]*UpdateTransaction{}
+	for _, t := range txns {
+		if !n.RenameEdited && t.OriginalName != "" && t.Payee != t.OriginalName {
+			continue
+		}
+
+		name := n.Transaction(t)
+		if name == "" || name == t.Payee {
+			continue
+		}
+		ret[t.ID] = &UpdateTransaction{Payee: &name}
+	}
+
+	return ret
+}
+
+// CleanPayee applies the built-in cleanup rules to a raw payee without any
+// aliases. All caps names are title cased, mixed case names are left alone.
+func CleanPayee(raw string) string {
+	s := strings.TrimSpace(raw)
+
+	// Bank exports often pad the merchant from the location with runs of
+	// spaces, so only the first column is the merchant.
+	if parts := multiSpace.Split(s, 2); len(parts) > 1 && parts[0] != "" {
+		s = parts[0]
+	}
+
+	for {
+		stripped := processorPrefix.ReplaceAllString(bankPrefix.ReplaceAllString(s, ""), "")
+		if stripped == s {
+			break
+		}
+		s = stripped
+	}
+
+	s = phone.ReplaceAllString(s, "")
+	s = storeNumber.ReplaceAllString(s, "")
+	s = usLocation.ReplaceAllString(s, "")
+	s = trailingJunk.ReplaceAllString(s, "")
+	s = strings.TrimSpace(s)
+
+	if s == strings.ToUpper(s) {
+		s = titleCase(s)
+	}
+
+	return s
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+
+	return strings.Join(words, " ")
+}
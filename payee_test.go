@@ -0,0 +1,57 @@
+package lunchmoney
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanPayee(t *testing.T) {
+	tests := map[string]string{
+		"SQ *BLUE BOTTLE 1234":                 "Blue Bottle",
+		"TST* SWEETGREEN #0456 NEW YORK NY":    "Sweetgreen",
+		"STARBUCKS STORE 01234 SEATTLE WA":     "Starbucks",
+		"PAYPAL *NETFLIX.COM 4029357733 CA":    "Netflix.com",
+		"WHOLEFDS MKT      AUSTIN       TX":    "Wholefds Mkt",
+		"POS DEBIT TRADER JOE'S # 552":         "Trader Joe's",
+		"McDonald's":                           "McDonald's",
+		"SPOTIFY USA NY 10011":                 "Spotify Usa",
+		"  Amazon.com*AB12CD34E Amzn.com/bill": "Amazon.com*AB12CD34E Amzn.com/bill",
+	}
+
+	for raw, want := range tests {
+		t.Run(raw, func(t *testing.T) {
+			assert.Equal(t, want, CleanPayee(raw))
+		})
+	}
+}
+
+func TestPayeeNormalizer(t *testing.T) {
+	n := NewPayeeNormalizer()
+	require.NoError(t, n.LoadAliases(strings.NewReader(`{
+		"aliases": {"WHOLEFDS MKT": "Whole Foods"},
+		"patterns": [{"pattern": "(?i)^amazon\\.com|^amzn", "name": "Amazon"}]
+	}`)))
+
+	assert.Equal(t, "Whole Foods", n.Normalize("WHOLEFDS MKT 10234 AUSTIN TX"))
+	assert.Equal(t, "Amazon", n.Normalize("AMZN Mktp US*2K4"))
+	assert.Equal(t, "Blue Bottle", n.Normalize("SQ *BLUE BOTTLE 1234"))
+
+	txns := []*Transaction{
+		{ID: 1, Payee: "SQ *BLUE BOTTLE 1234"},
+		{ID: 2, Payee: "Whole Foods", OriginalName: "WHOLEFDS MKT 10234"},
+		{ID: 3, Payee: "Groceries", OriginalName: "WHOLEFDS MKT 10234"},
+		{ID: 4, Payee: "WHOLEFDS MKT 10234", OriginalName: "WHOLEFDS MKT 10234"},
+	}
+	updates := n.PayeeUpdates(txns)
+	require.Len(t, updates, 2, "the payee renamed by hand is kept")
+	assert.Equal(t, "Blue Bottle", *updates[1].Payee)
+	assert.Equal(t, "Whole Foods", *updates[4].Payee)
+
+	n.RenameEdited = true
+	updates = n.PayeeUpdates(txns)
+	require.Len(t, updates, 3)
+	assert.Equal(t, "Whole Foods", *updates[3].Payee)
+}
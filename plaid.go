@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/Rhymond/go-money"
-	"github.com/go-playground/validator/v10"
 )
 
 // PlaidAccountsResponse is a list plaid accounts response.
@@ -32,6 +31,25 @@ type PlaidAccount struct {
 	Currency          string    `json:"currency"`
 	BalanceLastUpdate time.Time `json:"balance_last_update"`
 	Limit             int64     `json:"limit"`
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PlaidAccount) UnmarshalJSON(data []byte) error {
+	type plain PlaidAccount
+	extra, err := unmarshalExtra(data, (*plain)(p))
+	p.Extra = extra
+
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p PlaidAccount) MarshalJSON() ([]byte, error) {
+	type plain PlaidAccount
+
+	return marshalExtra(plain(p), p.Extra)
 }
 
 // ParsedAmount converts the Plaid account balance and currency into a money.Money object.
@@ -41,26 +59,32 @@ func (p *PlaidAccount) ParsedAmount() (*money.Money, error) {
 	return ParseCurrency(p.Balance, p.Currency)
 }
 
-// GetPlaidAccounts retrieves all Plaid-connected accounts from the Lunch Money API.
+// List retrieves all Plaid-connected accounts from the Lunch Money API.
 // It returns a slice of PlaidAccount objects containing information about each account,
 // including balance, institution information, and status. Returns an error if the request fails.
-func (c *Client) GetPlaidAccounts(ctx context.Context) ([]*PlaidAccount, error) {
-	validate := validator.New()
+func (s *plaidAccountService) List(ctx context.Context) ([]*PlaidAccount, error) {
 	options := map[string]string{}
 
-	body, err := c.Get(ctx, "/v1/plaid_accounts", options)
+	body, err := s.c.Get(ctx, "/v1/plaid_accounts", options)
 	if err != nil {
 		return nil, fmt.Errorf("get plaid accounts: %w", err)
 	}
 
 	resp := &PlaidAccountsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/plaid_accounts", body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	if err := validate.Struct(resp); err != nil {
+	if err := s.c.checkResponse(ctx, "/v1/plaid_accounts", resp); err != nil {
 		return nil, err
 	}
 
 	return resp.PlaidAccounts, nil
 }
+
+// GetPlaidAccounts retrieves all Plaid-connected accounts.
+//
+// Deprecated: Use Client.Plaid.List.
+func (c *Client) GetPlaidAccounts(ctx context.Context) ([]*PlaidAccount, error) {
+	return c.PlaidAccountService().List(ctx)
+}
@@ -0,0 +1,58 @@
+package lunchmoney
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces requests out so that no more than one starts every
+// interval. It is safe for concurrent use.
+type RateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing perSecond requests a second.
+// A rate of zero or less means no limit, and returns nil, which Wait and
+// Client.Limiter treat as such.
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+
+	return &RateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// Wait blocks until the next request is allowed to start or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package lunchmoney
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	for _, rate := range []float64{0, -1} {
+		l := NewRateLimiter(rate)
+		assert.Nil(t, l, "rate %v", rate)
+		require.NoError(t, l.Wait(ctx), "a nil limiter doesn't wait")
+	}
+
+	l := NewRateLimiter(50)
+	start := time.Now()
+	for range 3 {
+		require.NoError(t, l.Wait(ctx))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "three requests are two intervals apart")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, l.Wait(canceled), context.Canceled)
+}
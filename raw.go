@@ -0,0 +1,87 @@
+package lunchmoney
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// RawResponse is an API response as it came over the wire.
+type RawResponse struct {
+	Method string
+	Path   string
+	Status int
+	Body   []byte
+}
+
+// RawResponses collects the responses of calls made with a context from
+// WithRawResponses. It is safe for concurrent use.
+type RawResponses struct {
+	mu   sync.Mutex
+	list []RawResponse
+}
+
+// List returns the responses collected so far, in the order they arrived.
+func (r *RawResponses) List() []RawResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]RawResponse(nil), r.list...)
+}
+
+// Last returns the most recent response, or false if there is none.
+func (r *RawResponses) Last() (RawResponse, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.list) == 0 {
+		return RawResponse{}, false
+	}
+
+	return r.list[len(r.list)-1], true
+}
+
+func (r *RawResponses) add(resp RawResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list = append(r.list, resp)
+}
+
+type rawResponsesKey struct{}
+
+// WithRawResponses returns a context that collects the bodies of the API
+// responses to calls made with it into r, for reading fields the models
+// don't have yet:
+//
+//	var raw lunchmoney.RawResponses
+//	user, err := client.User.Get(lunchmoney.WithRawResponses(ctx, &raw))
+//	resp, _ := raw.Last()
+//
+// Results served from a cache have no response.
+func WithRawResponses(ctx context.Context, r *RawResponses) context.Context {
+	return context.WithValue(ctx, rawResponsesKey{}, r)
+}
+
+// captureRaw records resp in the request context's RawResponses, if it has
+// one, and replaces its body with a copy.
+func captureRaw(req *http.Request, resp *http.Response) error {
+	r, ok := req.Context().Value(rawResponsesKey{}).(*RawResponses)
+	if !ok {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+
+	r.add(RawResponse{Method: req.Method, Path: req.URL.Path, Status: resp.StatusCode, Body: body})
+
+	return nil
+}
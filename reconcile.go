@@ -0,0 +1,353 @@
+package lunchmoney
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const dateFormat = "2006-01-02"
+
+// ReconcileOptions tune how recurring expenses are matched against transactions.
+type ReconcileOptions struct {
+	// DateWindow is how many days either side of the billing date a
+	// transaction can land and still count as the expected occurrence.
+	// Defaults to 3.
+	DateWindow int
+	// AmountTolerance is the relative difference (0.05 is 5%) allowed between
+	// the expected and actual amount before a match is reported as drifted.
+	// Defaults to 0.01. Unregistered charges are grouped with the same
+	// tolerance.
+	AmountTolerance float64
+	// Lookback is how many days before the period Client.ReconcileRecurring
+	// fetches transactions from, so charges that repeat across earlier months
+	// can be reported as unregistered. Defaults to 90.
+	Lookback int
+}
+
+func (o *ReconcileOptions) withDefaults() ReconcileOptions {
+	ret := ReconcileOptions{DateWindow: 3, AmountTolerance: 0.01, Lookback: 90}
+	if o == nil {
+		return ret
+	}
+
+	if o.DateWindow > 0 {
+		ret.DateWindow = o.DateWindow
+	}
+
+	if o.AmountTolerance > 0 {
+		ret.AmountTolerance = o.AmountTolerance
+	}
+
+	if o.Lookback > 0 {
+		ret.Lookback = o.Lookback
+	}
+
+	return ret
+}
+
+// RecurringMatch pairs an expected recurring expense occurrence with the
+// transaction that fulfilled it.
+type RecurringMatch struct {
+	Expense     *RecurringExpense
+	Transaction *Transaction
+	// Expected and Actual are the absolute amounts in the expense and
+	// transaction currencies respectively.
+	Expected float64
+	Actual   float64
+}
+
+// Drift is the relative change between the expected and actual amount, so a
+// subscription going from 10.00 to 12.00 has a drift of 0.2.
+func (m *RecurringMatch) Drift() float64 {
+	if m.Expected == 0 {
+		return 0
+	}
+
+	return (m.Actual - m.Expected) / m.Expected
+}
+
+// RecurringReconciliation is the result of comparing the recurring expenses
+// expected in a period against the transactions that actually happened.
+type RecurringReconciliation struct {
+	// Matched occurrences had a transaction with the expected amount.
+	Matched []*RecurringMatch
+	// AmountChanged occurrences had a transaction, but for a different amount
+	// (or currency) than expected.
+	AmountChanged []*RecurringMatch
+	// Missed occurrences had no matching transaction.
+	Missed []*RecurringExpense
+	// Unregistered transactions look recurring, but aren't attached to any of
+	// the expected recurring expenses. A transaction looks recurring if Lunch
+	// Money suggested it as one, or if it repeats at a regular cadence with
+	// the same payee and an amount within the tolerance.
+	Unregistered []*Transaction
+}
+
+// ReconcileRecurring compares recurring expense occurrences against
+// transactions. Each RecurringExpense is treated as one expected occurrence on
+// its BillingDate, which is how the API returns them. Transactions are matched
+// by RecurringID first, then by the expense's TransactionID, and finally by
+// payee within the date window.
+func ReconcileRecurring(expenses []*RecurringExpense, txns []*Transaction, opts *ReconcileOptions) (*RecurringReconciliation, error) {
+	o := opts.withDefaults()
+	window := time.Duration(o.DateWindow) * 24 * time.Hour
+
+	dates := make(map[int64]time.Time, len(txns))
+	for _, t := range txns {
+		d, err := time.Parse(dateFormat, t.Date)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d has bad date %q: %w", t.ID, t.Date, err)
+		}
+		dates[t.ID] = d
+	}
+
+	occurrences := make([]*RecurringExpense, len(expenses))
+	copy(occurrences, expenses)
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].BillingDate < occurrences[j].BillingDate
+	})
+
+	ret := &RecurringReconciliation{}
+	used := map[int64]bool{}
+	for _, e := range occurrences {
+		billed, err := time.Parse(dateFormat, e.BillingDate)
+		if err != nil {
+			return nil, fmt.Errorf("recurring expense %d has bad billing date %q: %w", e.ID, e.BillingDate, err)
+		}
+
+		var best *Transaction
+		bestRank := 0
+		var bestDistance time.Duration
+		for _, t := range txns {
+			if used[t.ID] {
+				continue
+			}
+
+			distance := dates[t.ID].Sub(billed).Abs()
+			if distance > window {
+				continue
+			}
+
+			rank := matchRank(e, t)
+			if rank == 0 {
+				continue
+			}
+
+			if best == nil || rank > bestRank || (rank == bestRank && distance < bestDistance) {
+				best, bestRank, bestDistance = t, rank, distance
+			}
+		}
+
+		if best == nil {
+			ret.Missed = append(ret.Missed, e)
+			continue
+		}
+		used[best.ID] = true
+
+		m, sameAmount, err := newRecurringMatch(e, best, o.AmountTolerance)
+		if err != nil {
+			return nil, err
+		}
+
+		if sameAmount {
+			ret.Matched = append(ret.Matched, m)
+		} else {
+			ret.AmountChanged = append(ret.AmountChanged, m)
+		}
+	}
+
+	var rest []*Transaction
+	for _, t := range txns {
+		if !used[t.ID] && !matchesAny(expenses, t) {
+			rest = append(rest, t)
+		}
+	}
+	ret.Unregistered = unregistered(rest, o.AmountTolerance)
+
+	return ret, nil
+}
+
+// matchRank scores how confident we are that t is an occurrence of e. Zero
+// means no match.
+func matchRank(e *RecurringExpense, t *Transaction) int {
+	switch {
+	case t.RecurringID != 0 && t.RecurringID == e.ID:
+		return 3
+	case e.TransactionID != 0 && t.ID == e.TransactionID:
+		return 2
+	case t.RecurringID == 0 && samePayee(e, t):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func newRecurringMatch(e *RecurringExpense, t *Transaction, tolerance float64) (*RecurringMatch, bool, error) {
+	expected, err := absAmount(e.Amount)
+	if err != nil {
+		return nil, false, fmt.Errorf("recurring expense %d: %w", e.ID, err)
+	}
+
+	actual, err := absAmount(t.Amount)
+	if err != nil {
+		return nil, false, fmt.Errorf("transaction %d: %w", t.ID, err)
+	}
+
+	m := &RecurringMatch{Expense: e, Transaction: t, Expected: expected, Actual: actual}
+	same := strings.EqualFold(e.Currency, t.Currency) && withinTolerance(expected, actual, tolerance)
+
+	return m, same, nil
+}
+
+// matchesAny reports whether t belongs to one of the registered expenses,
+// even if it wasn't matched to an occurrence in the period.
+func matchesAny(expenses []*RecurringExpense, t *Transaction) bool {
+	for _, e := range expenses {
+		if matchRank(e, t) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unregistered picks the transactions, none of which belong to a registered
+// expense, that seem to be recurring charges nobody registered: ones Lunch
+// Money suggested, and ones DiscoverSubscriptions groups into a repeating
+// charge by payee and amount.
+func unregistered(txns []*Transaction, tolerance float64) []*Transaction {
+	repeating := map[int64]bool{}
+	for _, c := range DiscoverSubscriptions(txns, &SubscriptionOptions{MinOccurrences: 2, AmountTolerance: tolerance}) {
+		for _, t := range c.Transactions {
+			repeating[t.ID] = true
+		}
+	}
+
+	var ret []*Transaction
+	for _, t := range txns {
+		if t.RecurringID != 0 {
+			continue
+		}
+
+		if repeating[t.ID] || t.RecurringType == "suggested" {
+			ret = append(ret, t)
+		}
+	}
+
+	return ret
+}
+
+func samePayee(e *RecurringExpense, t *Transaction) bool {
+	want := []string{normalizePayee(e.Payee), normalizePayee(e.OriginalName)}
+	got := []string{normalizePayee(t.Payee), normalizePayee(t.OriginalName)}
+	for _, w := range want {
+		for _, g := range got {
+			if w == "" || g == "" {
+				continue
+			}
+
+			if strings.HasPrefix(g, w) || strings.HasPrefix(w, g) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// normalizePayee lowercases a payee and strips everything but letters, so
+// "NETFLIX.COM 1234" and "Netflix.com" compare equal. samePayee also accepts
+// one being a prefix of the other, so "Netflix" matches "NETFLIX.COM".
+func normalizePayee(p string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(p) {
+		if unicode.IsLetter(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func absAmount(amount string) (float64, error) {
+	f, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not valid float: %w", amount, err)
+	}
+
+	return math.Abs(f), nil
+}
+
+func withinTolerance(expected, actual, tolerance float64) bool {
+	if expected == 0 {
+		return actual == 0
+	}
+
+	return math.Abs(actual-expected)/expected <= tolerance
+}
+
+// ReconcileRecurring fetches the recurring expenses and transactions between
+// startDate and endDate (inclusive, YYYY-MM-DD) and reconciles them with
+// ReconcileRecurring. Transactions are fetched with the date window added on
+// both sides so charges that land just outside the period still match, and
+// from Lookback days before the period so repeating charges can be spotted.
+func (c *Client) ReconcileRecurring(ctx context.Context, startDate, endDate string, opts *ReconcileOptions) (*RecurringReconciliation, error) {
+	start, err := time.Parse(dateFormat, startDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date: %w", err)
+	}
+
+	end, err := time.Parse(dateFormat, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date: %w", err)
+	}
+
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date %s is before start date %s", endDate, startDate)
+	}
+
+	// Recurring expenses are returned for a whole month at a time.
+	var expenses []*RecurringExpense
+	for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(end); month = month.AddDate(0, 1, 0) {
+		res, err := c.RecurringExpenseService().List(ctx, &RecurringExpenseFilters{StartDate: month.Format(dateFormat)})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range res {
+			if e.BillingDate >= startDate && e.BillingDate <= endDate {
+				expenses = append(expenses, e)
+			}
+		}
+	}
+
+	o := opts.withDefaults()
+	txnStart := start.AddDate(0, 0, -max(o.DateWindow, o.Lookback)).Format(dateFormat)
+	txnEnd := end.AddDate(0, 0, o.DateWindow).Format(dateFormat)
+	txns, err := c.TransactionService().List(ctx, &TransactionFilters{StartDate: &txnStart, EndDate: &txnEnd})
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := ReconcileRecurring(expenses, txns, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only report unregistered charges from inside the requested period.
+	var unregistered []*Transaction
+	for _, t := range ret.Unregistered {
+		if t.Date >= startDate && t.Date <= endDate {
+			unregistered = append(unregistered, t)
+		}
+	}
+	ret.Unregistered = unregistered
+
+	return ret, nil
+}
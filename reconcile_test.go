@@ -0,0 +1,145 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileRecurring(t *testing.T) {
+	expenses := []*RecurringExpense{
+		{ID: 1, Payee: "Netflix", Amount: "15.4900", Currency: "usd", BillingDate: "2023-03-05"},
+		{ID: 2, Payee: "Spotify", Amount: "9.9900", Currency: "usd", BillingDate: "2023-03-10"},
+		{ID: 3, Payee: "Gym", Amount: "40.0000", Currency: "usd", BillingDate: "2023-03-15"},
+		{ID: 4, Payee: "Rent", Amount: "1500.0000", Currency: "usd", BillingDate: "2023-03-01"},
+	}
+	txns := []*Transaction{
+		{ID: 10, Date: "2023-03-06", Payee: "NETFLIX.COM", Amount: "15.4900", Currency: "usd"},
+		{ID: 11, Date: "2023-03-10", Payee: "Spotify USA", Amount: "11.9900", Currency: "usd", RecurringID: 2},
+		{ID: 12, Date: "2023-03-20", Payee: "Gym", Amount: "40.0000", Currency: "usd"},
+		{ID: 13, Date: "2023-03-01", Payee: "Landlord", Amount: "1500.0000", Currency: "usd", RecurringID: 4},
+		{ID: 14, Date: "2023-03-22", Payee: "Hulu", Amount: "7.9900", Currency: "usd", RecurringType: "suggested"},
+		{ID: 15, Date: "2023-03-23", Payee: "Coffee", Amount: "4.5000", Currency: "usd"},
+		{ID: 16, Date: "2023-03-25", Payee: "Netflix", Amount: "15.4900", Currency: "usd", RecurringType: "suggested"},
+	}
+
+	got, err := ReconcileRecurring(expenses, txns, nil)
+	require.NoError(t, err)
+
+	matched := map[int64]int64{}
+	for _, m := range got.Matched {
+		matched[m.Expense.ID] = m.Transaction.ID
+	}
+	assert.Equal(t, map[int64]int64{1: 10, 4: 13}, matched)
+
+	require.Len(t, got.AmountChanged, 1)
+	assert.Equal(t, int64(2), got.AmountChanged[0].Expense.ID)
+	assert.InDelta(t, 0.2002, got.AmountChanged[0].Drift(), 0.0001)
+
+	require.Len(t, got.Missed, 1)
+	assert.Equal(t, int64(3), got.Missed[0].ID)
+
+	var unregistered []int64
+	for _, u := range got.Unregistered {
+		unregistered = append(unregistered, u.ID)
+	}
+	assert.Equal(t, []int64{14}, unregistered, "charges of registered expenses aren't unregistered")
+}
+
+func TestReconcileRecurringOptions(t *testing.T) {
+	expenses := []*RecurringExpense{
+		{ID: 1, Payee: "Gym", Amount: "40.0000", Currency: "usd", BillingDate: "2023-03-15"},
+	}
+	txns := []*Transaction{
+		{ID: 12, Date: "2023-03-20", Payee: "Gym", Amount: "41.0000", Currency: "usd"},
+	}
+
+	got, err := ReconcileRecurring(expenses, txns, &ReconcileOptions{DateWindow: 7, AmountTolerance: 0.05})
+	require.NoError(t, err)
+	require.Len(t, got.Matched, 1)
+	assert.Empty(t, got.Missed)
+	assert.Empty(t, got.Unregistered)
+}
+
+func TestReconcileRecurringRepeatingCharge(t *testing.T) {
+	expenses := []*RecurringExpense{
+		{ID: 1, Payee: "Netflix", Amount: "15.4900", Currency: "usd", BillingDate: "2023-03-05"},
+	}
+	txns := []*Transaction{
+		{ID: 10, Date: "2023-03-05", Payee: "Netflix", Amount: "15.4900", Currency: "usd"},
+		{ID: 20, Date: "2023-01-12", Payee: "HULU 1234", Amount: "7.9900", Currency: "usd"},
+		{ID: 21, Date: "2023-02-12", Payee: "Hulu", Amount: "7.9900", Currency: "usd"},
+		{ID: 22, Date: "2023-03-12", Payee: "Hulu", Amount: "7.9900", Currency: "usd"},
+		{ID: 30, Date: "2023-02-20", Payee: "Coffee", Amount: "4.5000", Currency: "usd"},
+		{ID: 31, Date: "2023-03-20", Payee: "Coffee", Amount: "6.2500", Currency: "usd"},
+	}
+
+	got, err := ReconcileRecurring(expenses, txns, nil)
+	require.NoError(t, err)
+
+	var unregistered []int64
+	for _, u := range got.Unregistered {
+		unregistered = append(unregistered, u.ID)
+		assert.Empty(t, u.RecurringType)
+	}
+	assert.Equal(t, []int64{20, 21, 22}, unregistered, "a repeated charge is found without a suggestion, but not one whose amount changes")
+}
+
+func TestReconcileRecurringBadDate(t *testing.T) {
+	_, err := ReconcileRecurring(nil, []*Transaction{{ID: 1, Date: "03/01/2023"}}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad date")
+}
+
+func TestClientReconcileRecurring(t *testing.T) {
+	var recurringQueries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/recurring_expenses":
+			recurringQueries = append(recurringQueries, r.URL.Query())
+			if r.URL.Query().Get("start_date") != "2023-03-01" {
+				_, _ = w.Write([]byte(`{"recurring_expenses": []}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"recurring_expenses": [
+				{"id": 1, "payee": "Netflix", "amount": "15.4900", "currency": "usd", "billing_date": "2023-03-05"},
+				{"id": 3, "payee": "Gym", "amount": "40.0000", "currency": "usd", "billing_date": "2023-03-15"}
+			]}`))
+		case "/v1/transactions":
+			assert.Equal(t, "2022-12-01", r.URL.Query().Get("start_date"))
+			assert.Equal(t, "2023-04-03", r.URL.Query().Get("end_date"))
+			_, _ = w.Write([]byte(`{"transactions": [
+				{"id": 10, "date": "2023-03-06", "payee": "NETFLIX.COM", "amount": "15.4900", "currency": "usd"},
+				{"id": 14, "date": "2023-03-22", "payee": "Hulu", "amount": "7.9900", "currency": "usd", "recurring_type": "suggested"},
+				{"id": 17, "date": "2023-04-02", "payee": "Spotify", "amount": "9.9900", "currency": "usd", "recurring_type": "suggested"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+
+	got, err := client.ReconcileRecurring(context.Background(), "2023-03-01", "2023-03-31", &ReconcileOptions{DateWindow: 3})
+	require.NoError(t, err)
+
+	require.Len(t, recurringQueries, 1)
+	assert.Equal(t, url.Values{"start_date": {"2023-03-01"}}, recurringQueries[0])
+
+	require.Len(t, got.Matched, 1)
+	assert.Equal(t, int64(10), got.Matched[0].Transaction.ID)
+	require.Len(t, got.Missed, 1)
+	assert.Equal(t, int64(3), got.Missed[0].ID)
+	require.Len(t, got.Unregistered, 1, "unregistered charges outside the period are dropped")
+	assert.Equal(t, int64(14), got.Unregistered[0].ID)
+}
@@ -33,6 +33,25 @@ type RecurringExpense struct {
 	PlaidAccountID int64     `json:"plaid_account_id"`
 	AssetID        int64     `json:"asset_id"`
 	TransactionID  int64     `json:"transaction_id"`
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *RecurringExpense) UnmarshalJSON(data []byte) error {
+	type plain RecurringExpense
+	extra, err := unmarshalExtra(data, (*plain)(r))
+	r.Extra = extra
+
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r RecurringExpense) MarshalJSON() ([]byte, error) {
+	type plain RecurringExpense
+
+	return marshalExtra(plain(r), r.Extra)
 }
 
 // ParsedAmount converts the recurring expense's amount and currency into a money.Money object.
@@ -49,26 +68,24 @@ type RecurringExpenseFilters struct {
 }
 
 // ToMap converts the recurring expense filters to a string map to be sent with the request as
-// GET parameters. This method formats filter parameters correctly for the Lunch Money API.
-// It marshals the filter struct to JSON and then unmarshals it to a string map.
+// GET parameters. Unset fields are left out of the map.
 func (r *RecurringExpenseFilters) ToMap() (map[string]string, error) {
 	ret := map[string]string{}
-	b, err := json.Marshal(r)
-	if err != nil {
-		return nil, err
+	if r.StartDate != "" {
+		ret["start_date"] = r.StartDate
 	}
 
-	if err := json.Unmarshal(b, &ret); err != nil {
-		return nil, err
+	if r.DebitAsNegative {
+		ret["debit_as_negative"] = fmt.Sprintf("%t", r.DebitAsNegative)
 	}
 
 	return ret, nil
 }
 
-// GetRecurringExpenses retrieves all recurring expenses from the Lunch Money API based on the provided filters.
+// List retrieves all recurring expenses from the Lunch Money API based on the provided filters.
 // It returns a slice of RecurringExpense objects or an error if the request fails.
 // The filters parameter can be used to specify date ranges and other criteria.
-func (c *Client) GetRecurringExpenses(ctx context.Context, filters *RecurringExpenseFilters) ([]*RecurringExpense, error) {
+func (s *recurringExpenseService) List(ctx context.Context, filters *RecurringExpenseFilters) ([]*RecurringExpense, error) {
 	validate := validator.New()
 	options := map[string]string{}
 	if filters != nil {
@@ -83,19 +100,26 @@ func (c *Client) GetRecurringExpenses(ctx context.Context, filters *RecurringExp
 		options = maps
 	}
 
-	body, err := c.Get(ctx, "/v1/recurring_expenses", options)
+	body, err := s.c.Get(ctx, "/v1/recurring_expenses", options)
 	if err != nil {
 		return nil, fmt.Errorf("get recurring expenses: %w", err)
 	}
 
 	resp := &RecurringExpensesResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/recurring_expenses", body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	if err := validate.Struct(resp); err != nil {
+	if err := s.c.checkResponse(ctx, "/v1/recurring_expenses", resp); err != nil {
 		return nil, err
 	}
 
 	return resp.RecurringExpenses, nil
 }
+
+// GetRecurringExpenses retrieves recurring expenses matching filters.
+//
+// Deprecated: Use Client.Recurring.List.
+func (c *Client) GetRecurringExpenses(ctx context.Context, filters *RecurringExpenseFilters) ([]*RecurringExpense, error) {
+	return c.RecurringExpenseService().List(ctx, filters)
+}
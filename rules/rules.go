@@ -0,0 +1,351 @@
+// Package rules categorizes and tags transactions locally. Rules match on
+// payee, original name, amount, account and day of week, and their actions
+// are turned into UpdateTransaction changes that can be reviewed as a diff
+// before being applied.
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icco/lunchmoney"
+)
+
+// Condition describes which transactions a rule applies to. Every set field
+// must match; an empty Condition matches everything.
+type Condition struct {
+	// Payee and OriginalName are regular expressions.
+	Payee        string `json:"payee,omitempty"`
+	OriginalName string `json:"original_name,omitempty"`
+	// MinAmount and MaxAmount are inclusive bounds on the transaction amount.
+	MinAmount       *float64 `json:"min_amount,omitempty"`
+	MaxAmount       *float64 `json:"max_amount,omitempty"`
+	AssetIDs        []int64  `json:"asset_ids,omitempty"`
+	PlaidAccountIDs []int64  `json:"plaid_account_ids,omitempty"`
+	// Weekdays are English day names, like "saturday".
+	Weekdays []string `json:"weekdays,omitempty"`
+}
+
+// Action describes what a matching rule changes.
+type Action struct {
+	CategoryID *int64   `json:"category_id,omitempty"`
+	AddTags    []string `json:"add_tags,omitempty"`
+	Payee      *string  `json:"payee,omitempty"`
+	Notes      *string  `json:"notes,omitempty"`
+	Clear      bool     `json:"clear,omitempty"`
+}
+
+// Rule is a named condition and action. Rules are evaluated in order and
+// later rules override fields set by earlier ones, unless an earlier rule has
+// Stop set.
+type Rule struct {
+	Name string    `json:"name"`
+	If   Condition `json:"if"`
+	Then Action    `json:"then"`
+	Stop bool      `json:"stop,omitempty"`
+}
+
+type compiledRule struct {
+	Rule
+	payee        *regexp.Regexp
+	originalName *regexp.Regexp
+	weekdays     map[time.Weekday]bool
+}
+
+// Engine evaluates a list of rules.
+type Engine struct {
+	rules []*compiledRule
+}
+
+// New compiles rules into an Engine.
+func New(rules []Rule) (*Engine, error) {
+	e := &Engine{}
+	for i, r := range rules {
+		cr := &compiledRule{Rule: r}
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+			cr.Name = name
+		}
+
+		var err error
+		if r.If.Payee != "" {
+			if cr.payee, err = regexp.Compile(r.If.Payee); err != nil {
+				return nil, fmt.Errorf("rule %s: payee: %w", name, err)
+			}
+		}
+
+		if r.If.OriginalName != "" {
+			if cr.originalName, err = regexp.Compile(r.If.OriginalName); err != nil {
+				return nil, fmt.Errorf("rule %s: original name: %w", name, err)
+			}
+		}
+
+		if len(r.If.Weekdays) > 0 {
+			cr.weekdays = map[time.Weekday]bool{}
+			for _, d := range r.If.Weekdays {
+				wd, ok := weekdays[strings.ToLower(d)]
+				if !ok {
+					return nil, fmt.Errorf("rule %s: unknown weekday %q", name, d)
+				}
+				cr.weekdays[wd] = true
+			}
+		}
+
+		e.rules = append(e.rules, cr)
+	}
+
+	return e, nil
+}
+
+// Load reads a JSON array of rules and compiles them.
+func Load(r io.Reader) (*Engine, error) {
+	var rules []Rule
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("decode rules: %w", err)
+	}
+
+	return New(rules)
+}
+
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[strings.ToLower(d.String())] = d
+	}
+}
+
+func (r *compiledRule) matches(t *lunchmoney.Transaction) bool {
+	if r.payee != nil && !r.payee.MatchString(t.Payee) {
+		return false
+	}
+
+	if r.originalName != nil && !r.originalName.MatchString(t.OriginalName) {
+		return false
+	}
+
+	if r.If.MinAmount != nil || r.If.MaxAmount != nil {
+		amount, err := strconv.ParseFloat(t.Amount, 64)
+		if err != nil {
+			return false
+		}
+
+		if r.If.MinAmount != nil && amount < *r.If.MinAmount {
+			return false
+		}
+
+		if r.If.MaxAmount != nil && amount > *r.If.MaxAmount {
+			return false
+		}
+	}
+
+	if len(r.If.AssetIDs) > 0 && !contains(r.If.AssetIDs, t.AssetID) {
+		return false
+	}
+
+	if len(r.If.PlaidAccountIDs) > 0 && !contains(r.If.PlaidAccountIDs, t.PlaidAccountID) {
+		return false
+	}
+
+	if r.weekdays != nil {
+		d, err := time.Parse("2006-01-02", t.Date)
+		if err != nil || !r.weekdays[d.Weekday()] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldChange is a single field a Change would modify.
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// Change is the update rules produced for one transaction.
+type Change struct {
+	Transaction *lunchmoney.Transaction
+	Rules       []string
+	Update      *lunchmoney.UpdateTransaction
+	Diff        []FieldChange
+}
+
+// Plan is the set of changes produced by evaluating rules.
+type Plan struct {
+	Changes []*Change
+}
+
+// Evaluate runs the rules over txns and returns the changes that would be
+// made. Transactions the rules wouldn't modify are left out.
+func (e *Engine) Evaluate(txns []*lunchmoney.Transaction) *Plan {
+	p := &Plan{}
+	for _, t := range txns {
+		var act Action
+		var matched []string
+		for _, r := range e.rules {
+			if !r.matches(t) {
+				continue
+			}
+
+			matched = append(matched, r.Name)
+			merge(&act, r.Then)
+			if r.Stop {
+				break
+			}
+		}
+
+		if c := newChange(t, matched, act); c != nil {
+			p.Changes = append(p.Changes, c)
+		}
+	}
+
+	return p
+}
+
+func merge(dst *Action, src Action) {
+	if src.CategoryID != nil {
+		dst.CategoryID = src.CategoryID
+	}
+
+	if src.Payee != nil {
+		dst.Payee = src.Payee
+	}
+
+	if src.Notes != nil {
+		dst.Notes = src.Notes
+	}
+
+	dst.AddTags = append(dst.AddTags, src.AddTags...)
+	dst.Clear = dst.Clear || src.Clear
+}
+
+func newChange(t *lunchmoney.Transaction, matched []string, act Action) *Change {
+	c := &Change{Transaction: t, Rules: matched, Update: &lunchmoney.UpdateTransaction{}}
+
+	if act.CategoryID != nil && *act.CategoryID != t.CategoryID {
+		c.Update.CategoryID = lunchmoney.Set(*act.CategoryID)
+		from := t.CategoryName
+		if from == "" && t.CategoryID != 0 {
+			from = strconv.FormatInt(t.CategoryID, 10)
+		}
+		c.Diff = append(c.Diff, FieldChange{Field: "category", From: from, To: strconv.FormatInt(*act.CategoryID, 10)})
+	}
+
+	if act.Payee != nil && *act.Payee != t.Payee {
+		c.Update.Payee = act.Payee
+		c.Diff = append(c.Diff, FieldChange{Field: "payee", From: t.Payee, To: *act.Payee})
+	}
+
+	if act.Notes != nil && *act.Notes != t.Notes {
+		c.Update.Notes = act.Notes
+		c.Diff = append(c.Diff, FieldChange{Field: "notes", From: t.Notes, To: *act.Notes})
+	}
+
+	if act.Clear && t.Status != "cleared" {
+		status := "cleared"
+		c.Update.Status = &status
+		c.Diff = append(c.Diff, FieldChange{Field: "status", From: t.Status, To: status})
+	}
+
+	if tags, from, to := addTags(t.Tags, act.AddTags); tags != nil {
+		c.Update.Tags = tags
+		c.Diff = append(c.Diff, FieldChange{Field: "tags", From: from, To: to})
+	}
+
+	if len(c.Diff) == 0 {
+		return nil
+	}
+
+	return c
+}
+
+// addTags returns the full tag list to send if any of names are missing from
+// existing, along with before and after descriptions for the diff.
+func addTags(existing []lunchmoney.Tag, names []string) ([]lunchmoney.TagRef, string, string) {
+	have := map[string]bool{}
+	var refs []lunchmoney.TagRef
+	var before []string
+	for _, t := range existing {
+		have[strings.ToLower(t.Name)] = true
+		refs = append(refs, lunchmoney.TagByID(int64(t.ID)))
+		before = append(before, t.Name)
+	}
+
+	after := append([]string{}, before...)
+	added := false
+	for _, n := range names {
+		if have[strings.ToLower(n)] {
+			continue
+		}
+		have[strings.ToLower(n)] = true
+		refs = append(refs, lunchmoney.TagByName(n))
+		after = append(after, n)
+		added = true
+	}
+
+	if !added {
+		return nil, "", ""
+	}
+
+	return refs, strings.Join(before, ", "), strings.Join(after, ", ")
+}
+
+// Updates returns the plan's updates keyed by transaction ID, ready for
+// Client.Transactions.BulkUpdate.
+func (p *Plan) Updates() map[int64]*lunchmoney.UpdateTransaction {
+	ret := make(map[int64]*lunchmoney.UpdateTransaction, len(p.Changes))
+	for _, c := range p.Changes {
+		ret[c.Transaction.ID] = c.Update
+	}
+
+	return ret
+}
+
+// WriteDiff writes a human readable description of the plan to w, for dry
+// runs.
+func (p *Plan) WriteDiff(w io.Writer) error {
+	changes := append([]*Change{}, p.Changes...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Transaction.ID < changes[j].Transaction.ID
+	})
+
+	for _, c := range changes {
+		t := c.Transaction
+		if _, err := fmt.Fprintf(w, "#%d %s %s %s %s (%s)\n", t.ID, t.Date, t.Payee, t.Amount, t.Currency, strings.Join(c.Rules, ", ")); err != nil {
+			return err
+		}
+
+		for _, d := range c.Diff {
+			if _, err := fmt.Fprintf(w, "  %s: %q -> %q\n", d.Field, d.From, d.To); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply sends the plan's updates with Client.Transactions.BulkUpdate.
+func (p *Plan) Apply(ctx context.Context, c *lunchmoney.Client, opts *lunchmoney.BulkUpdateOptions) (map[int64]*lunchmoney.BulkUpdateResult, error) {
+	return c.TransactionService().BulkUpdate(ctx, p.Updates(), opts)
+}
@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/icco/lunchmoney"
+	"github.com/icco/lunchmoney/lunchmoneytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `[
+  {
+    "name": "coffee",
+    "if": {"payee": "(?i)starbucks|blue bottle", "max_amount": 20},
+    "then": {"category_id": 12, "add_tags": ["coffee"], "clear": true}
+  },
+  {
+    "name": "weekend",
+    "if": {"weekdays": ["saturday", "sunday"]},
+    "then": {"add_tags": ["weekend"]}
+  },
+  {
+    "name": "rent",
+    "if": {"original_name": "^ACH LANDLORD", "asset_ids": [153]},
+    "then": {"payee": "Rent", "notes": "monthly rent"},
+    "stop": true
+  },
+  {
+    "name": "never reached for rent",
+    "if": {"payee": "Rent"},
+    "then": {"notes": "overridden"}
+  }
+]`
+
+func TestEvaluate(t *testing.T) {
+	e, err := Load(strings.NewReader(testRules))
+	require.NoError(t, err)
+
+	txns := []*lunchmoney.Transaction{
+		// Sunday.
+		{ID: 1, Date: "2023-01-01", Payee: "STARBUCKS 123", Amount: "4.5000", Currency: "usd", Status: "uncleared", Tags: []lunchmoney.Tag{{ID: 7, Name: "food"}}},
+		// Too expensive for the coffee rule, and a Tuesday.
+		{ID: 2, Date: "2023-01-03", Payee: "Blue Bottle", Amount: "45.0000", Currency: "usd"},
+		{ID: 3, Date: "2023-01-03", Payee: "ACH LANDLORD CO", OriginalName: "ACH LANDLORD CO", Amount: "1500.0000", Currency: "usd", AssetID: 153},
+		// Already in the right state.
+		{ID: 4, Date: "2023-01-03", Payee: "Starbucks", Amount: "3.0000", Currency: "usd", CategoryID: 12, Status: "cleared", Tags: []lunchmoney.Tag{{ID: 8, Name: "Coffee"}}},
+	}
+
+	p := e.Evaluate(txns)
+	require.Len(t, p.Changes, 2)
+
+	c := p.Changes[0]
+	assert.Equal(t, []string{"coffee", "weekend"}, c.Rules)
+	id, ok := c.Update.CategoryID.Get()
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), id)
+	assert.Equal(t, []lunchmoney.TagRef{lunchmoney.TagByID(7), lunchmoney.TagByName("coffee"), lunchmoney.TagByName("weekend")}, c.Update.Tags)
+	require.NotNil(t, c.Update.Status)
+	assert.Equal(t, "cleared", *c.Update.Status)
+
+	rent := p.Changes[1]
+	assert.Equal(t, []string{"rent"}, rent.Rules)
+	assert.Equal(t, "monthly rent", *rent.Update.Notes)
+	assert.Equal(t, "Rent", *rent.Update.Payee)
+
+	assert.Len(t, p.Updates(), 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, p.WriteDiff(&buf))
+	assert.Contains(t, buf.String(), "#1 2023-01-01 STARBUCKS 123 4.5000 usd (coffee, weekend)")
+	assert.Contains(t, buf.String(), `  tags: "food" -> "food, coffee, weekend"`)
+	assert.Contains(t, buf.String(), `  payee: "ACH LANDLORD CO" -> "Rent"`)
+}
+
+func TestNewInvalid(t *testing.T) {
+	_, err := New([]Rule{{Name: "bad", If: Condition{Payee: "("}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule bad: payee")
+
+	_, err = New([]Rule{{If: Condition{Weekdays: []string{"funday"}}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `rule #1: unknown weekday "funday"`)
+}
+
+func TestApplyClientLiteral(t *testing.T) {
+	srv := lunchmoneytest.NewServer(nil)
+	defer srv.Close()
+	base := srv.Client()
+	client := &lunchmoney.Client{HTTP: base.HTTP, Base: base.Base}
+
+	notes := "checked"
+	p := &Plan{Changes: []*Change{{
+		Transaction: srv.Data().Transactions[0],
+		Update:      &lunchmoney.UpdateTransaction{Notes: &notes},
+	}}}
+	results, err := p.Apply(context.Background(), client, nil)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, notes, srv.Data().Transactions[0].Notes)
+}
@@ -0,0 +1,191 @@
+package lunchmoney
+
+import "context"
+
+//go:generate moq -pkg lunchmoneymock -out lunchmoneymock/lunchmoneymock.go . API TransactionService CategoryService TagService BudgetService AssetService PlaidAccountService CryptoService RecurringExpenseService UserService
+
+// TransactionService reads and writes transactions. It is Client.Transactions.
+type TransactionService interface {
+	List(ctx context.Context, filters *TransactionFilters) ([]*Transaction, error)
+	Get(ctx context.Context, id int64, filters *TransactionFilters) (*Transaction, error)
+	Create(ctx context.Context, itReq InsertTransactionsRequest) (*InsertTransactionsResponse, error)
+	Update(ctx context.Context, id int64, ut *UpdateTransaction) (*UpdateTransactionResp, error)
+	UpdateWithOptions(ctx context.Context, id int64, ut *UpdateTransaction, opts *UpdateTransactionOptions) (*UpdateTransactionResp, error)
+	BulkUpdate(ctx context.Context, updates map[int64]*UpdateTransaction, opts *BulkUpdateOptions) (map[int64]*BulkUpdateResult, error)
+}
+
+// CategoryService reads categories. It is Client.Categories.
+type CategoryService interface {
+	List(ctx context.Context) ([]*Category, error)
+	Get(ctx context.Context, id int64) (*Category, error)
+}
+
+// TagService reads tags. It is Client.Tags.
+type TagService interface {
+	List(ctx context.Context) ([]*Tag, error)
+}
+
+// BudgetService reads budgets. It is Client.Budgets.
+type BudgetService interface {
+	List(ctx context.Context, filters *BudgetFilters) ([]*Budget, error)
+}
+
+// AssetService reads and updates manually-managed assets. It is
+// Client.Assets.
+type AssetService interface {
+	List(ctx context.Context) ([]*Asset, error)
+	Update(ctx context.Context, id int64, asset *UpdateAsset) (*Asset, error)
+}
+
+// PlaidAccountService reads Plaid accounts. It is Client.Plaid.
+type PlaidAccountService interface {
+	List(ctx context.Context) ([]*PlaidAccount, error)
+}
+
+// CryptoService reads crypto balances. It is Client.Crypto.
+type CryptoService interface {
+	List(ctx context.Context) ([]*Crypto, error)
+}
+
+// RecurringExpenseService reads recurring expenses. It is Client.Recurring.
+type RecurringExpenseService interface {
+	List(ctx context.Context, filters *RecurringExpenseFilters) ([]*RecurringExpense, error)
+}
+
+// UserService reads the current user. It is Client.User.
+type UserService interface {
+	Get(ctx context.Context) (*User, error)
+}
+
+// API is every Lunch Money endpoint the Client supports, as the flat set of
+// methods it had before the services. *Client still implements it, and the
+// lunchmoneymock package has a mock of it, so code written against it keeps
+// working; new code should depend on the services instead. Helpers built
+// from several endpoints, such as NetWorth, are left out.
+type API interface {
+	GetTransactions(ctx context.Context, filters *TransactionFilters) ([]*Transaction, error)
+	GetTransaction(ctx context.Context, id int64, filters *TransactionFilters) (*Transaction, error)
+	InsertTransactions(ctx context.Context, itReq InsertTransactionsRequest) (*InsertTransactionsResponse, error)
+	UpdateTransaction(ctx context.Context, id int64, ut *UpdateTransaction) (*UpdateTransactionResp, error)
+	UpdateTransactionWithOptions(ctx context.Context, id int64, ut *UpdateTransaction, opts *UpdateTransactionOptions) (*UpdateTransactionResp, error)
+	UpdateTransactions(ctx context.Context, updates map[int64]*UpdateTransaction, opts *BulkUpdateOptions) (map[int64]*BulkUpdateResult, error)
+	GetCategories(ctx context.Context) ([]*Category, error)
+	GetCategory(ctx context.Context, id int64) (*Category, error)
+	GetTags(ctx context.Context) ([]*Tag, error)
+	GetBudgets(ctx context.Context, filters *BudgetFilters) ([]*Budget, error)
+	GetAssets(ctx context.Context) ([]*Asset, error)
+	UpdateAsset(ctx context.Context, id int64, asset *UpdateAsset) (*Asset, error)
+	GetPlaidAccounts(ctx context.Context) ([]*PlaidAccount, error)
+	GetCrypto(ctx context.Context) ([]*Crypto, error)
+	GetRecurringExpenses(ctx context.Context, filters *RecurringExpenseFilters) ([]*RecurringExpense, error)
+	GetUser(ctx context.Context) (*User, error)
+}
+
+var _ API = (*Client)(nil)
+
+type transactionService struct{ c *Client }
+
+type categoryService struct{ c *Client }
+
+type tagService struct{ c *Client }
+
+type budgetService struct{ c *Client }
+
+type assetService struct{ c *Client }
+
+type plaidAccountService struct{ c *Client }
+
+type cryptoService struct{ c *Client }
+
+type recurringExpenseService struct{ c *Client }
+
+type userService struct{ c *Client }
+
+var (
+	_ TransactionService      = (*transactionService)(nil)
+	_ CategoryService         = (*categoryService)(nil)
+	_ TagService              = (*tagService)(nil)
+	_ BudgetService           = (*budgetService)(nil)
+	_ AssetService            = (*assetService)(nil)
+	_ PlaidAccountService     = (*plaidAccountService)(nil)
+	_ CryptoService           = (*cryptoService)(nil)
+	_ RecurringExpenseService = (*recurringExpenseService)(nil)
+	_ UserService             = (*userService)(nil)
+)
+
+// Code using a Client's services should get them through the methods below
+// rather than its fields: they return the field if it is set and the
+// default service otherwise, so a Client built as a struct literal, which
+// has none set, still works.
+
+// TransactionService returns c.Transactions, or the default service if it is nil.
+func (c *Client) TransactionService() TransactionService {
+	if c.Transactions != nil {
+		return c.Transactions
+	}
+	return &transactionService{c}
+}
+
+// CategoryService returns c.Categories, or the default service if it is nil.
+func (c *Client) CategoryService() CategoryService {
+	if c.Categories != nil {
+		return c.Categories
+	}
+	return &categoryService{c}
+}
+
+// TagService returns c.Tags, or the default service if it is nil.
+func (c *Client) TagService() TagService {
+	if c.Tags != nil {
+		return c.Tags
+	}
+	return &tagService{c}
+}
+
+// BudgetService returns c.Budgets, or the default service if it is nil.
+func (c *Client) BudgetService() BudgetService {
+	if c.Budgets != nil {
+		return c.Budgets
+	}
+	return &budgetService{c}
+}
+
+// AssetService returns c.Assets, or the default service if it is nil.
+func (c *Client) AssetService() AssetService {
+	if c.Assets != nil {
+		return c.Assets
+	}
+	return &assetService{c}
+}
+
+// PlaidAccountService returns c.Plaid, or the default service if it is nil.
+func (c *Client) PlaidAccountService() PlaidAccountService {
+	if c.Plaid != nil {
+		return c.Plaid
+	}
+	return &plaidAccountService{c}
+}
+
+// CryptoService returns c.Crypto, or the default service if it is nil.
+func (c *Client) CryptoService() CryptoService {
+	if c.Crypto != nil {
+		return c.Crypto
+	}
+	return &cryptoService{c}
+}
+
+// RecurringExpenseService returns c.Recurring, or the default service if it is nil.
+func (c *Client) RecurringExpenseService() RecurringExpenseService {
+	if c.Recurring != nil {
+		return c.Recurring
+	}
+	return &recurringExpenseService{c}
+}
+
+// UserService returns c.User, or the default service if it is nil.
+func (c *Client) UserService() UserService {
+	if c.User != nil {
+		return c.User
+	}
+	return &userService{c}
+}
@@ -0,0 +1,86 @@
+package lunchmoney
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServicesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	responses := map[string]string{
+		"/v1/categories":     `{"categories": [{"id": 1, "name": "Groceries"}, {"id": 2, "name": "Rent"}]}`,
+		"/v1/categories/2":   `{"id": 2, "name": "Rent"}`,
+		"/v1/transactions/7": `{"updated": true}`,
+		"/v1/me":             `{"user_id": 1, "user_name": "User 1", "primary_currency": "cad"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			resp = `{"error": "not found"}`
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestServices(t *testing.T) {
+	server := newServicesServer(t)
+	client, err := NewClient("test-token")
+	require.NoError(t, err)
+	client.Base, err = url.Parse(server.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+	assert.Len(t, client.TokenID(), 16)
+	assert.NotContains(t, client.TokenID(), "test-token")
+
+	categories, err := client.Categories.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, categories, 2)
+	assert.Equal(t, "Groceries", categories[0].Name)
+
+	category, err := client.Categories.Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Rent", category.Name)
+
+	notes := "paid"
+	results, err := client.Transactions.BulkUpdate(ctx, map[int64]*UpdateTransaction{7: {Notes: &notes}}, nil)
+	require.NoError(t, err)
+	assert.True(t, results[7].Response.Updated)
+}
+
+func TestClientWithoutNewClient(t *testing.T) {
+	server := newServicesServer(t)
+	base, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	// A Client built by hand has no services set.
+	client := &Client{HTTP: server.Client(), Base: base}
+	ctx := context.Background()
+	assert.Empty(t, client.TokenID())
+
+	categories, err := client.GetCategories(ctx)
+	require.NoError(t, err)
+	assert.Len(t, categories, 2)
+
+	category, err := client.GetCategory(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Rent", category.Name)
+
+	notes := "paid"
+	_, err = client.UpdateTransactions(ctx, map[int64]*UpdateTransaction{7: {Notes: &notes}}, nil)
+	require.NoError(t, err)
+
+	conv, err := client.NewConverter(ctx)
+	require.NoError(t, err, "helpers fall back to the built in services")
+	assert.Equal(t, "cad", conv.Primary)
+}
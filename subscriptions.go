@@ -0,0 +1,301 @@
+package lunchmoney
+
+import (
+	"cmp"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SubscriptionOptions tune how DiscoverSubscriptions clusters transactions.
+type SubscriptionOptions struct {
+	// MinOccurrences is how many charges a cluster needs before a cadence is
+	// inferred. Defaults to 3.
+	MinOccurrences int
+	// AmountTolerance is the relative difference (0.05 is 5%) allowed between
+	// charges in the same cluster. Defaults to 0.05.
+	AmountTolerance float64
+	// MinConfidence drops candidates scoring below it. Defaults to 0.5.
+	MinConfidence float64
+	// Normalizer, if set, groups transactions by canonical merchant name
+	// instead of by letters in the payee, and names candidates with it.
+	Normalizer *PayeeNormalizer
+}
+
+func (o *SubscriptionOptions) withDefaults() SubscriptionOptions {
+	ret := SubscriptionOptions{MinOccurrences: 3, AmountTolerance: 0.05, MinConfidence: 0.5}
+	if o == nil {
+		return ret
+	}
+	ret.Normalizer = o.Normalizer
+
+	if o.MinOccurrences > 0 {
+		ret.MinOccurrences = o.MinOccurrences
+	}
+
+	if o.AmountTolerance > 0 {
+		ret.AmountTolerance = o.AmountTolerance
+	}
+
+	if o.MinConfidence > 0 {
+		ret.MinConfidence = o.MinConfidence
+	}
+
+	return ret
+}
+
+// SubscriptionCandidate is a group of transactions that look like a recurring
+// expense Lunch Money doesn't know about yet.
+type SubscriptionCandidate struct {
+	Payee    string
+	Amount   string
+	Currency string
+	// Cadence uses the same names as RecurringExpense.Cadence.
+	Cadence      string
+	IntervalDays float64
+	FirstDate    string
+	LastDate     string
+	NextDate     string
+	// Confidence is between 0 and 1 and combines how regular the intervals
+	// are, how stable the amount is and how many charges were seen.
+	Confidence   float64
+	Transactions []*Transaction
+}
+
+// ToRecurringExpense proposes a RecurringExpense from the candidate. The
+// Lunch Money v1 API has no endpoint for creating recurring expenses, so this
+// is meant for reviewing and entering them in the app by hand.
+func (s *SubscriptionCandidate) ToRecurringExpense() *RecurringExpense {
+	ret := &RecurringExpense{
+		StartDate:   s.FirstDate,
+		Cadence:     s.Cadence,
+		Payee:       s.Payee,
+		Amount:      s.Amount,
+		Currency:    s.Currency,
+		BillingDate: s.NextDate,
+		Type:        "suggested",
+		Source:      "manual",
+	}
+
+	if len(s.Transactions) > 0 {
+		last := s.Transactions[len(s.Transactions)-1]
+		ret.OriginalName = last.OriginalName
+		ret.AssetID = last.AssetID
+		ret.PlaidAccountID = last.PlaidAccountID
+	}
+
+	return ret
+}
+
+var cadences = []struct {
+	name     string
+	days     float64
+	slack    float64
+	addMonth int
+	addDay   int
+}{
+	{"once a week", 7, 1.5, 0, 7},
+	{"every 2 weeks", 14, 2.5, 0, 14},
+	{"monthly", 30.4, 4, 1, 0},
+	{"every 3 months", 91.3, 10, 3, 0},
+	{"every 4 months", 121.7, 12, 4, 0},
+	{"twice a year", 182.6, 15, 6, 0},
+	{"yearly", 365.2, 20, 12, 0},
+}
+
+type subscriptionTxn struct {
+	t      *Transaction
+	date   time.Time
+	amount float64
+}
+
+// DiscoverSubscriptions looks through transactions for repeating charges that
+// aren't attached to a recurring expense. Transactions are clustered by
+// normalized payee, currency and amount, and each cluster's cadence is
+// inferred from the median interval between charges. Candidates are sorted by
+// descending confidence.
+func DiscoverSubscriptions(txns []*Transaction, opts *SubscriptionOptions) []*SubscriptionCandidate {
+	o := opts.withDefaults()
+
+	groups := map[string][]subscriptionTxn{}
+	var keys []string
+	for _, t := range txns {
+		if t.RecurringID != 0 || t.IsGroup {
+			continue
+		}
+
+		var key string
+		if o.Normalizer != nil {
+			key = strings.ToLower(o.Normalizer.Transaction(t))
+		} else {
+			key = normalizePayee(cmp.Or(t.Payee, t.OriginalName))
+		}
+		if key == "" {
+			continue
+		}
+
+		date, err := time.Parse(dateFormat, t.Date)
+		if err != nil {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(t.Amount, 64)
+		if err != nil || amount == 0 {
+			continue
+		}
+
+		key += "/" + strings.ToLower(t.Currency)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], subscriptionTxn{t: t, date: date, amount: amount})
+	}
+
+	var ret []*SubscriptionCandidate
+	for _, key := range keys {
+		for _, cluster := range clusterByAmount(groups[key], o.AmountTolerance) {
+			if len(cluster) < o.MinOccurrences {
+				continue
+			}
+
+			c := newSubscriptionCandidate(cluster)
+			if c == nil || c.Confidence < o.MinConfidence {
+				continue
+			}
+
+			if o.Normalizer != nil {
+				c.Payee = o.Normalizer.Transaction(c.Transactions[len(c.Transactions)-1])
+			}
+			ret = append(ret, c)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Confidence > ret[j].Confidence
+	})
+
+	return ret
+}
+
+// clusterByAmount splits txns into runs of similar amounts. Each cluster is
+// anchored on its smallest amount, so a slow drift upwards doesn't chain
+// unrelated charges together.
+func clusterByAmount(txns []subscriptionTxn, tolerance float64) [][]subscriptionTxn {
+	sort.SliceStable(txns, func(i, j int) bool {
+		return txns[i].amount < txns[j].amount
+	})
+
+	var ret [][]subscriptionTxn
+	var current []subscriptionTxn
+	for _, t := range txns {
+		if len(current) > 0 && !withinTolerance(math.Abs(current[0].amount), math.Abs(t.amount), tolerance) {
+			ret = append(ret, current)
+			current = nil
+		}
+		current = append(current, t)
+	}
+
+	if len(current) > 0 {
+		ret = append(ret, current)
+	}
+
+	return ret
+}
+
+func newSubscriptionCandidate(cluster []subscriptionTxn) *SubscriptionCandidate {
+	sort.SliceStable(cluster, func(i, j int) bool {
+		return cluster[i].date.Before(cluster[j].date)
+	})
+
+	var intervals []float64
+	for i := 1; i < len(cluster); i++ {
+		days := cluster[i].date.Sub(cluster[i-1].date).Hours() / 24
+		if days == 0 {
+			// Two charges on the same day aren't a cadence.
+			return nil
+		}
+		intervals = append(intervals, days)
+	}
+
+	interval := median(intervals)
+	cadenceIdx := -1
+	for i, c := range cadences {
+		if math.Abs(interval-c.days) <= c.slack {
+			cadenceIdx = i
+			break
+		}
+	}
+	if cadenceIdx < 0 {
+		return nil
+	}
+	cadence := cadences[cadenceIdx]
+
+	amounts := make([]float64, len(cluster))
+	txns := make([]*Transaction, len(cluster))
+	for i, t := range cluster {
+		amounts[i] = t.amount
+		txns[i] = t.t
+	}
+
+	// Regularity is how far intervals stray from the cadence relative to its
+	// slack, stability is the amount's coefficient of variation and coverage
+	// saturates at six charges.
+	var deviation float64
+	for _, d := range intervals {
+		deviation += math.Min(1, math.Abs(d-cadence.days)/cadence.slack)
+	}
+	regularity := 1 - deviation/float64(len(intervals))
+	stability := 1 - math.Min(1, stddev(amounts)/math.Abs(mean(amounts)))
+	coverage := math.Min(1, float64(len(cluster))/6)
+	confidence := regularity*0.6 + stability*0.2 + coverage*0.2
+
+	last := cluster[len(cluster)-1]
+	return &SubscriptionCandidate{
+		Payee:        last.t.Payee,
+		Amount:       last.t.Amount,
+		Currency:     last.t.Currency,
+		Cadence:      cadence.name,
+		IntervalDays: interval,
+		FirstDate:    cluster[0].t.Date,
+		LastDate:     last.t.Date,
+		NextDate:     last.date.AddDate(0, cadence.addMonth, cadence.addDay).Format(dateFormat),
+		Confidence:   math.Round(confidence*100) / 100,
+		Transactions: txns,
+	}
+}
+
+func median(vs []float64) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+
+	s := make([]float64, len(vs))
+	copy(s, vs)
+	sort.Float64s(s)
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+func mean(vs []float64) float64 {
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+
+	return sum / float64(len(vs))
+}
+
+func stddev(vs []float64) float64 {
+	m := mean(vs)
+	var sum float64
+	for _, v := range vs {
+		sum += (v - m) * (v - m)
+	}
+
+	return math.Sqrt(sum / float64(len(vs)))
+}
@@ -0,0 +1,39 @@
+package lunchmoney
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverSubscriptions(t *testing.T) {
+	txns := []*Transaction{
+		{ID: 1, Date: "2023-01-03", Payee: "DISNEY PLUS 0012", Amount: "10.9900", Currency: "usd"},
+		{ID: 2, Date: "2023-02-03", Payee: "Disney Plus", Amount: "10.9900", Currency: "usd"},
+		{ID: 3, Date: "2023-03-04", Payee: "DISNEY PLUS 0012", Amount: "10.9900", Currency: "usd"},
+		{ID: 4, Date: "2023-04-03", Payee: "DISNEY PLUS 0012", Amount: "10.9900", Currency: "usd"},
+		// Same payee, very different amount: not part of the subscription.
+		{ID: 5, Date: "2023-02-14", Payee: "Disney Plus", Amount: "89.9900", Currency: "usd"},
+		// Already a known recurring expense.
+		{ID: 6, Date: "2023-01-01", Payee: "Rent", Amount: "1500.0000", Currency: "usd", RecurringID: 7},
+		{ID: 7, Date: "2023-02-01", Payee: "Rent", Amount: "1500.0000", Currency: "usd", RecurringID: 7},
+		{ID: 8, Date: "2023-03-01", Payee: "Rent", Amount: "1500.0000", Currency: "usd", RecurringID: 7},
+		// Irregular.
+		{ID: 9, Date: "2023-01-05", Payee: "Coffee", Amount: "4.5000", Currency: "usd"},
+		{ID: 10, Date: "2023-01-06", Payee: "Coffee", Amount: "4.5000", Currency: "usd"},
+		{ID: 11, Date: "2023-03-20", Payee: "Coffee", Amount: "4.5000", Currency: "usd"},
+	}
+
+	got := DiscoverSubscriptions(txns, nil)
+	require.Len(t, got, 1)
+	assert.Equal(t, "monthly", got[0].Cadence)
+	assert.Equal(t, "2023-01-03", got[0].FirstDate)
+	assert.Equal(t, "2023-05-03", got[0].NextDate)
+	assert.Len(t, got[0].Transactions, 4)
+	assert.Greater(t, got[0].Confidence, 0.8)
+
+	re := got[0].ToRecurringExpense()
+	assert.Equal(t, "monthly", re.Cadence)
+	assert.Equal(t, "10.9900", re.Amount)
+}
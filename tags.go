@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-
-	"github.com/go-playground/validator/v10"
 )
 
 // TagsResponse is the response from getting all tags.
@@ -16,30 +14,55 @@ type Tag struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type plain Tag
+	extra, err := unmarshalExtra(data, (*plain)(t))
+	t.Extra = extra
+
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	type plain Tag
+
+	return marshalExtra(plain(t), t.Extra)
 }
 
-// GetTags retrieves all tags from the Lunch Money API.
+// List retrieves all tags from the Lunch Money API.
 // It returns a slice of Tag objects containing tag details such as ID, name, and description.
 // Returns an error if the request fails or if any tag fails validation.
-func (c *Client) GetTags(ctx context.Context) ([]*Tag, error) {
-	validate := validator.New()
-	body, err := c.Get(ctx, "/v1/tags", nil)
+func (s *tagService) List(ctx context.Context) ([]*Tag, error) {
+	body, err := s.c.Get(ctx, "/v1/tags", nil)
 	if err != nil {
 		return nil, fmt.Errorf("get tags: %w", err)
 	}
 
 	resp := &TagsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/tags", body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
 	ret := []*Tag(*resp)
 
 	for _, t := range ret {
-		if err := validate.Struct(t); err != nil {
+		if err := s.c.checkResponse(ctx, "/v1/tags", t); err != nil {
 			return nil, err
 		}
 	}
 
 	return ret, nil
 }
+
+// GetTags retrieves all tags.
+//
+// Deprecated: Use Client.Tags.List.
+func (c *Client) GetTags(ctx context.Context) ([]*Tag, error) {
+	return c.TagService().List(ctx)
+}
@@ -0,0 +1,265 @@
+// Package telemetry adds OpenTelemetry tracing and metrics to a Lunch Money
+// client. It is a separate package so programs that don't import it don't
+// link OpenTelemetry.
+//
+//	client, err := lunchmoney.NewClient(token)
+//	...
+//	telemetry.Instrument(client)
+//
+// Every API call gets a client span named after the resource and operation,
+// such as "lunchmoney transactions.list", and is a child of the span in the
+// request's context. The instruments recorded are:
+//
+//   - lunchmoney.client.duration, a histogram of call latency in seconds
+//   - lunchmoney.client.errors, a counter of failed calls by status code
+//   - lunchmoney.client.retries, a counter of requests resent after the
+//     first attempt, for example after a 429
+//
+// Retries are counted when a transport below the Transport resends the
+// request, so call Instrument after installing a retrying transport. A 429
+// that isn't retried is counted as an error.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/icco/lunchmoney"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/icco/lunchmoney/telemetry"
+
+// Attribute keys set on spans and metrics, in addition to the semantic
+// convention HTTP attributes.
+const (
+	ResourceKey    = attribute.Key("lunchmoney.resource")
+	OperationKey   = attribute.Key("lunchmoney.operation")
+	ResultCountKey = attribute.Key("lunchmoney.result_count")
+	ResendCountKey = attribute.Key("http.request.resend_count")
+)
+
+// Option configures Instrument and NewTransport.
+type Option func(*config)
+
+type config struct {
+	tp trace.TracerProvider
+	mp metric.MeterProvider
+}
+
+// WithTracerProvider sets the tracer provider. Defaults to the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tp = tp }
+}
+
+// WithMeterProvider sets the meter provider. Defaults to the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.mp = mp }
+}
+
+// Instrument wraps client's transport with NewTransport.
+func Instrument(client *lunchmoney.Client, opts ...Option) error {
+	t, err := NewTransport(client.HTTP.Transport, opts...)
+	if err != nil {
+		return err
+	}
+	client.HTTP.Transport = t
+
+	return nil
+}
+
+// Transport is an http.RoundTripper that traces and measures Lunch Money
+// API calls.
+type Transport struct {
+	next     http.RoundTripper
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+	retries  metric.Int64Counter
+}
+
+// NewTransport returns a Transport sending requests on to next, or to
+// http.DefaultTransport if next is nil.
+func NewTransport(next http.RoundTripper, opts ...Option) (*Transport, error) {
+	cfg := &config{tp: otel.GetTracerProvider(), mp: otel.GetMeterProvider()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	meter := cfg.mp.Meter(ScopeName)
+	duration, err := meter.Float64Histogram("lunchmoney.client.duration",
+		metric.WithDescription("Duration of Lunch Money API calls."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	errCount, err := meter.Int64Counter("lunchmoney.client.errors",
+		metric.WithDescription("Lunch Money API calls that failed, by status code."),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		return nil, err
+	}
+
+	retries, err := meter.Int64Counter("lunchmoney.client.retries",
+		metric.WithDescription("Lunch Money API requests resent after the first attempt."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transport{
+		next:     next,
+		tracer:   cfg.tp.Tracer(ScopeName),
+		duration: duration,
+		errors:   errCount,
+		retries:  retries,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource, operation := Operation(req.Method, req.URL.Path)
+	attrs := []attribute.KeyValue{
+		ResourceKey.String(resource),
+		OperationKey.String(operation),
+		attribute.String("http.request.method", req.Method),
+	}
+
+	ctx, span := t.tracer.Start(req.Context(), "lunchmoney "+resource+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(attribute.String("url.path", req.URL.Path)))
+	defer span.End()
+
+	// Count how many times the request is written, so resends by the
+	// transports below are seen.
+	var writes atomic.Int64
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) { writes.Add(1) },
+	})
+	retried := metric.WithAttributes(attrs...)
+	defer func() {
+		if n := writes.Load() - 1; n > 0 {
+			span.SetAttributes(ResendCountKey.Int64(n))
+			t.retries.Add(ctx, n, retried)
+		}
+	}()
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		t.record(ctx, start, append(attrs, attribute.String("error.type", "transport")), true)
+		return nil, err
+	}
+
+	// The result count needs the body. The client reads all of it anyway.
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	failed := resp.StatusCode >= http.StatusBadRequest || err != nil
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case failed:
+		span.SetStatus(codes.Error, resp.Status)
+	case operation == "list" || operation == "create":
+		if n, ok := resultCount(body); ok {
+			span.SetAttributes(ResultCountKey.Int(n))
+		}
+	}
+	t.record(ctx, start, attrs, failed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (t *Transport) record(ctx context.Context, start time.Time, attrs []attribute.KeyValue, failed bool) {
+	set := metric.WithAttributes(attrs...)
+
+	t.duration.Record(ctx, time.Since(start).Seconds(), set)
+	if failed {
+		t.errors.Add(ctx, 1, set)
+	}
+}
+
+// Operation names the resource and operation of an API call, for example
+// ("transactions", "get") for GET /v1/transactions/1.
+func Operation(method, path string) (resource, operation string) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/v1"), "/"), "/")
+	resource = parts[0]
+	if resource == "me" {
+		resource = "user"
+	}
+	item := len(parts) > 1 || resource == "user"
+
+	switch method {
+	case http.MethodGet:
+		operation = "list"
+		if item {
+			operation = "get"
+		}
+	case http.MethodPost:
+		operation = "create"
+	case http.MethodPut:
+		operation = "update"
+	case http.MethodDelete:
+		operation = "delete"
+	default:
+		operation = strings.ToLower(method)
+	}
+
+	return resource, operation
+}
+
+// resultCount counts the objects in a list or create response: the length of
+// a top level array, or of the only array in a top level object, such as
+// {"transactions": [...]} or {"ids": [...]}.
+func resultCount(body []byte) (int, bool) {
+	var list []json.RawMessage
+	if err := json.Unmarshal(body, &list); err == nil {
+		return len(list), true
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return 0, false
+	}
+
+	n, found := 0, false
+	for _, v := range obj {
+		if err := json.Unmarshal(v, &list); err == nil {
+			if found {
+				return 0, false
+			}
+			n, found = len(list), true
+		}
+	}
+
+	return n, found
+}
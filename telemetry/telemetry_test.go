@@ -0,0 +1,156 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/icco/lunchmoney/lunchmoneytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOperation(t *testing.T) {
+	tests := []struct {
+		method, path        string
+		resource, operation string
+	}{
+		{http.MethodGet, "/v1/transactions", "transactions", "list"},
+		{http.MethodGet, "/v1/transactions/12", "transactions", "get"},
+		{http.MethodPost, "/v1/transactions", "transactions", "create"},
+		{http.MethodPut, "/v1/transactions/12", "transactions", "update"},
+		{http.MethodPut, "/v1/assets/3", "assets", "update"},
+		{http.MethodDelete, "/v1/categories/3", "categories", "delete"},
+		{http.MethodGet, "/v1/me", "user", "get"},
+		{http.MethodGet, "/v1/plaid_accounts", "plaid_accounts", "list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			resource, operation := Operation(tt.method, tt.path)
+			assert.Equal(t, tt.resource, resource)
+			assert.Equal(t, tt.operation, operation)
+		})
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	ctx := context.Background()
+	srv := lunchmoneytest.NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	require.NoError(t, Instrument(client, WithTracerProvider(tp), WithMeterProvider(mp)))
+
+	parent, root := tp.Tracer("test").Start(ctx, "handler")
+	_, err := client.Transactions.List(parent, nil)
+	require.NoError(t, err)
+
+	srv.Inject(lunchmoneytest.Fault{Path: "/v1/tags", Times: 1, Status: http.StatusTooManyRequests})
+	_, err = client.Tags.List(parent)
+	require.Error(t, err)
+	root.End()
+
+	ended := spans.Ended()
+	require.Len(t, ended, 3)
+
+	list := ended[0]
+	assert.Equal(t, "lunchmoney transactions.list", list.Name())
+	assert.Equal(t, root.SpanContext().SpanID(), list.Parent().SpanID())
+	assert.Contains(t, list.Attributes(), ResultCountKey.Int(2))
+	assert.Contains(t, list.Attributes(), attribute.Int("http.response.status_code", 200))
+	assert.Equal(t, codes.Unset, list.Status().Code)
+
+	tags := ended[1]
+	assert.Equal(t, "lunchmoney tags.list", tags.Name())
+	assert.Equal(t, codes.Error, tags.Status().Code)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	duration := metrics["lunchmoney.client.duration"].Data.(metricdata.Histogram[float64])
+	var calls uint64
+	for _, dp := range duration.DataPoints {
+		calls += dp.Count
+	}
+	assert.Equal(t, uint64(2), calls)
+
+	errs := metrics["lunchmoney.client.errors"].Data.(metricdata.Sum[int64])
+	require.Len(t, errs.DataPoints, 1)
+	assert.Equal(t, int64(1), errs.DataPoints[0].Value)
+	status, ok := errs.DataPoints[0].Attributes.Value("http.response.status_code")
+	require.True(t, ok)
+	assert.Equal(t, int64(http.StatusTooManyRequests), status.AsInt64())
+	assert.NotContains(t, metrics, "lunchmoney.client.retries", "nothing resent the 429")
+}
+
+// retryTransport resends a request once if the first response is a 429.
+type retryTransport struct {
+	next http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+
+	return t.next.RoundTrip(req)
+}
+
+func TestInstrumentRetries(t *testing.T) {
+	ctx := context.Background()
+	srv := lunchmoneytest.NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+	client.HTTP.Transport = &retryTransport{next: client.HTTP.Transport}
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	require.NoError(t, Instrument(client, WithTracerProvider(tp), WithMeterProvider(mp)))
+
+	srv.Inject(lunchmoneytest.Fault{Path: "/v1/tags", Times: 1, Status: http.StatusTooManyRequests})
+	_, err := client.Tags.List(ctx)
+	require.NoError(t, err, "the 429 is retried")
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	assert.Contains(t, ended[0].Attributes(), ResendCountKey.Int(1))
+	assert.Equal(t, codes.Unset, ended[0].Status().Code)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	assert.NotContains(t, metrics, "lunchmoney.client.errors")
+
+	retries := metrics["lunchmoney.client.retries"].Data.(metricdata.Sum[int64])
+	require.Len(t, retries.DataPoints, 1)
+	assert.Equal(t, int64(1), retries.DataPoints[0].Value)
+	resource, ok := retries.DataPoints[0].Attributes.Value(ResourceKey)
+	require.True(t, ok)
+	assert.Equal(t, "tags", resource.AsString())
+}
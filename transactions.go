@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/Rhymond/go-money"
 	"github.com/go-playground/validator/v10"
@@ -65,6 +67,25 @@ type Transaction struct {
 	DisplayNotes            string  `json:"display_notes"`
 	AccountDisplayName      string  `json:"account_display_name"`
 	Tags                    []Tag   `json:"tags"`
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type plain Transaction
+	extra, err := unmarshalExtra(data, (*plain)(t))
+	t.Extra = extra
+
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	type plain Transaction
+
+	return marshalExtra(plain(t), t.Extra)
 }
 
 // ParsedAmount converts the transaction's amount and currency into a money.Money object.
@@ -137,10 +158,10 @@ func (r *TransactionFilters) ToMap() (map[string]string, error) {
 	return ret, nil
 }
 
-// GetTransactions retrieves all transactions from the Lunch Money API based on the provided filters.
+// List retrieves all transactions from the Lunch Money API based on the provided filters.
 // It returns a slice of Transaction objects or an error if the request fails.
 // The filters parameter can be used to narrow down results by date range, category, and other criteria.
-func (c *Client) GetTransactions(ctx context.Context, filters *TransactionFilters) ([]*Transaction, error) {
+func (s *transactionService) List(ctx context.Context, filters *TransactionFilters) ([]*Transaction, error) {
 	validate := validator.New()
 	options := map[string]string{}
 	if filters != nil {
@@ -155,27 +176,34 @@ func (c *Client) GetTransactions(ctx context.Context, filters *TransactionFilter
 		options = maps
 	}
 
-	body, err := c.Get(ctx, "/v1/transactions", options)
+	body, err := s.c.Get(ctx, "/v1/transactions", options)
 	if err != nil {
 		return nil, fmt.Errorf("get transactions: %w", err)
 	}
 
 	resp := &TransactionsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/transactions", body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	if err := validate.Struct(resp); err != nil {
+	if err := s.c.checkResponse(ctx, "/v1/transactions", resp); err != nil {
 		return nil, err
 	}
 
 	return resp.Transactions, nil
 }
 
-// GetTransaction retrieves a single transaction from the Lunch Money API by its ID.
+// GetTransactions retrieves transactions matching filters.
+//
+// Deprecated: Use Client.Transactions.List.
+func (c *Client) GetTransactions(ctx context.Context, filters *TransactionFilters) ([]*Transaction, error) {
+	return c.TransactionService().List(ctx, filters)
+}
+
+// Get retrieves a single transaction from the Lunch Money API by its ID.
 // It returns the transaction details or an error if the request fails.
 // The filters parameter can be used to specify additional query parameters for the request.
-func (c *Client) GetTransaction(ctx context.Context, id int64, filters *TransactionFilters) (*Transaction, error) {
+func (s *transactionService) Get(ctx context.Context, id int64, filters *TransactionFilters) (*Transaction, error) {
 	validate := validator.New()
 	options := map[string]string{}
 	if filters != nil {
@@ -190,23 +218,31 @@ func (c *Client) GetTransaction(ctx context.Context, id int64, filters *Transact
 		options = maps
 	}
 
-	body, err := c.Get(ctx, fmt.Sprintf("/v1/transactions/%d", id), options)
+	path := fmt.Sprintf("/v1/transactions/%d", id)
+	body, err := s.c.Get(ctx, path, options)
 	if err != nil {
 		return nil, fmt.Errorf("get transaction %d: %w", id, err)
 	}
 
 	resp := &Transaction{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, path, body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	if err := validate.Struct(resp); err != nil {
+	if err := s.c.checkResponse(ctx, path, resp); err != nil {
 		return nil, err
 	}
 
 	return resp, nil
 }
 
+// GetTransaction retrieves a single transaction by its ID.
+//
+// Deprecated: Use Client.Transactions.Get.
+func (c *Client) GetTransaction(ctx context.Context, id int64, filters *TransactionFilters) (*Transaction, error) {
+	return c.TransactionService().Get(ctx, id, filters)
+}
+
 // InsertTransactionsRequest contains the data needed to create one or more transactions.
 // It includes options for how the transactions should be processed by the Lunch Money system.
 type InsertTransactionsRequest struct {
@@ -236,53 +272,83 @@ type InsertTransaction struct {
 	TagsIDs        []int  `json:"tags,omitempty"`
 }
 
-// InsertTransactionsResponse contains the IDs of transactions created through the InsertTransactions method.
+// InsertTransactionsResponse contains the IDs of transactions created through TransactionService.Create.
 // These IDs can be used to reference the newly created transactions in subsequent API calls.
 type InsertTransactionsResponse struct {
 	IDs []int64 `json:"ids"`
 }
 
-// InsertTransactions creates new transactions in the Lunch Money API.
+// Create creates new transactions in the Lunch Money API.
 // It takes an InsertTransactionsRequest with transaction details and options.
 // Returns the IDs of the created transactions or an error if the insertion fails.
-func (c *Client) InsertTransactions(ctx context.Context, itReq InsertTransactionsRequest) (*InsertTransactionsResponse, error) {
+func (s *transactionService) Create(ctx context.Context, itReq InsertTransactionsRequest) (*InsertTransactionsResponse, error) {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 	if err := validate.Struct(itReq); err != nil {
 		return nil, err
 	}
 
-	body, err := c.Post(ctx, "/v1/transactions", itReq)
+	body, err := s.c.Post(ctx, "/v1/transactions", itReq)
 	if err != nil {
 		return nil, fmt.Errorf("insert transaction: %w", err)
 	}
 
 	resp := &InsertTransactionsResponse{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/transactions", body, resp); err != nil {
 		return nil, fmt.Errorf("insert response decode error: %w", err)
 	}
 
 	return resp, nil
 }
 
+// InsertTransactions creates new transactions.
+//
+// Deprecated: Use Client.Transactions.Create.
+func (c *Client) InsertTransactions(ctx context.Context, itReq InsertTransactionsRequest) (*InsertTransactionsResponse, error) {
+	return c.TransactionService().Create(ctx, itReq)
+}
+
 // UpdateTransaction contains fields that can be updated for an existing transaction.
 // All fields are optional, and only non-nil fields will be sent in the update request.
-// This provides a flexible way to update specific fields without needing to include unchanged values.
+// The Nullable ID fields can also be cleared by setting them to Clear, which sends
+// an explicit null. Tags replaces the transaction's tags; a non-nil empty slice
+// removes them all.
 type UpdateTransaction struct {
-	Date        *string `json:"date,omitempty" validate:"omitnil,datetime=2006-01-02"`
-	CategoryID  *int    `json:"category_id,omitempty"`
-	Payee       *string `json:"payee,omitempty"`
-	Currency    *string `json:"currency,omitempty"`
-	AssetID     *int    `json:"asset_id,omitempty"`
-	RecurringID *int    `json:"recurring_id,omitempty"`
-	Notes       *string `json:"notes,omitempty"`
-	Status      *string `json:"status,omitempty" validate:"omitnil,oneof=cleared uncleared"`
-	ExternalID  *string `json:"external_id,omitempty"`
+	Date           *string         `json:"date,omitempty" validate:"omitnil,datetime=2006-01-02"`
+	CategoryID     Nullable[int64] `json:"category_id,omitzero"`
+	Payee          *string         `json:"payee,omitempty"`
+	Amount         *string         `json:"amount,omitempty" validate:"omitnil,numeric"`
+	Currency       *string         `json:"currency,omitempty"`
+	AssetID        Nullable[int64] `json:"asset_id,omitzero"`
+	PlaidAccountID Nullable[int64] `json:"plaid_account_id,omitzero"`
+	RecurringID    Nullable[int64] `json:"recurring_id,omitzero"`
+	Notes          *string         `json:"notes,omitempty"`
+	Status         *string         `json:"status,omitempty" validate:"omitnil,oneof=cleared uncleared"`
+	ExternalID     *string         `json:"external_id,omitempty" validate:"omitnil,max=75"`
+	Tags           []TagRef        `json:"tags,omitzero"`
+}
+
+// SetAmount sets both the amount and currency of the update from m.
+func (ut *UpdateTransaction) SetAmount(m *money.Money) {
+	amount := strconv.FormatFloat(m.AsMajorUnits(), 'f', m.Currency().Fraction, 64)
+	currency := strings.ToLower(m.Currency().Code)
+	ut.Amount = &amount
+	ut.Currency = &currency
+}
+
+// UpdateTransactionOptions are request level options for updating a transaction.
+type UpdateTransactionOptions struct {
+	// DebitAsNegative means a negative Amount is a debit rather than a credit.
+	DebitAsNegative bool
+	// SkipBalanceUpdate leaves the balance of the transaction's asset alone.
+	SkipBalanceUpdate bool
 }
 
 // UpdateRequest is the request body used to update a transaction in the Lunch Money API.
 // It wraps an UpdateTransaction object in the format expected by the API.
 type UpdateRequest struct {
-	Transaction *UpdateTransaction `json:"transaction"`
+	Transaction       *UpdateTransaction `json:"transaction"`
+	DebitAsNegative   bool               `json:"debit_as_negative,omitempty"`
+	SkipBalanceUpdate bool               `json:"skip_balance_update,omitempty"`
 }
 
 // UpdateTransactionResp is the response received from the API when updating a transaction.
@@ -293,24 +359,51 @@ type UpdateTransactionResp struct {
 	Split   []int `json:"split"`
 }
 
-// UpdateTransaction modifies an existing transaction with the specified ID.
+// Update modifies an existing transaction with the specified ID.
 // It takes an UpdateTransaction object with the fields to be updated.
 // Returns information about the update operation or an error if the update fails.
+func (s *transactionService) Update(ctx context.Context, id int64, ut *UpdateTransaction) (*UpdateTransactionResp, error) {
+	return s.UpdateWithOptions(ctx, id, ut, nil)
+}
+
+// UpdateTransaction modifies an existing transaction.
+//
+// Deprecated: Use Client.Transactions.Update.
 func (c *Client) UpdateTransaction(ctx context.Context, id int64, ut *UpdateTransaction) (*UpdateTransactionResp, error) {
+	return c.TransactionService().Update(ctx, id, ut)
+}
+
+// UpdateWithOptions is Update with request level options.
+// A nil opts behaves like Update.
+func (s *transactionService) UpdateWithOptions(ctx context.Context, id int64, ut *UpdateTransaction, opts *UpdateTransactionOptions) (*UpdateTransactionResp, error) {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 	if err := validate.Struct(ut); err != nil {
 		return nil, err
 	}
 
-	body, err := c.Put(ctx, fmt.Sprintf("/v1/transactions/%d", id), &UpdateRequest{Transaction: ut})
+	req := &UpdateRequest{Transaction: ut}
+	if opts != nil {
+		req.DebitAsNegative = opts.DebitAsNegative
+		req.SkipBalanceUpdate = opts.SkipBalanceUpdate
+	}
+
+	path := fmt.Sprintf("/v1/transactions/%d", id)
+	body, err := s.c.Put(ctx, path, req)
 	if err != nil {
 		return nil, fmt.Errorf("update transaction %d: %w", id, err)
 	}
 
 	resp := &UpdateTransactionResp{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, path, body, resp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
 	return resp, nil
 }
+
+// UpdateTransactionWithOptions is UpdateTransaction with request level options.
+//
+// Deprecated: Use Client.Transactions.UpdateWithOptions.
+func (c *Client) UpdateTransactionWithOptions(ctx context.Context, id int64, ut *UpdateTransaction, opts *UpdateTransactionOptions) (*UpdateTransactionResp, error) {
+	return c.TransactionService().UpdateWithOptions(ctx, id, ut, opts)
+}
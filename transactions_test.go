@@ -1,9 +1,17 @@
 package lunchmoney
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
+	"github.com/Rhymond/go-money"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTransactionFilters_ToMap(t *testing.T) {
@@ -78,3 +86,97 @@ func TestTransactionFilters_ToMap(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateTransaction(t *testing.T) {
+	payee := "Blue Bottle"
+	amount := func(m *money.Money) *UpdateTransaction {
+		ut := &UpdateTransaction{}
+		ut.SetAmount(m)
+		return ut
+	}
+
+	tests := []struct {
+		name string
+		ut   *UpdateTransaction
+		opts *UpdateTransactionOptions
+		want string
+	}{
+		{
+			name: "unset fields are omitted",
+			ut:   &UpdateTransaction{Payee: &payee},
+			want: `{"transaction": {"payee": "Blue Bottle"}}`,
+		},
+		{
+			name: "set ids and tags",
+			ut: &UpdateTransaction{
+				CategoryID:     Set[int64](12),
+				PlaidAccountID: Set[int64](34),
+				Tags:           []TagRef{TagByID(5), TagByName("coffee")},
+			},
+			want: `{"transaction": {"category_id": 12, "plaid_account_id": 34, "tags": [5, "coffee"]}}`,
+		},
+		{
+			name: "cleared fields are sent as null",
+			ut: &UpdateTransaction{
+				CategoryID:  Clear[int64](),
+				RecurringID: Clear[int64](),
+				Tags:        []TagRef{},
+			},
+			want: `{"transaction": {"category_id": null, "recurring_id": null, "tags": []}}`,
+		},
+		{
+			name: "amount with currency",
+			ut:   amount(money.New(-1250, "eur")),
+			want: `{"transaction": {"amount": "-12.50", "currency": "eur"}}`,
+		},
+		{
+			name: "request options",
+			ut:   &UpdateTransaction{Payee: &payee},
+			opts: &UpdateTransactionOptions{DebitAsNegative: true, SkipBalanceUpdate: true},
+			want: `{"transaction": {"payee": "Blue Bottle"}, "debit_as_negative": true, "skip_balance_update": true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/transactions/42", r.URL.Path)
+				assert.Equal(t, http.MethodPut, r.Method)
+				b, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.want, string(b))
+				_, err = w.Write([]byte(`{"updated": true}`))
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-token")
+			require.NoError(t, err)
+			client.Base, err = url.Parse(server.URL)
+			require.NoError(t, err)
+
+			got, err := client.UpdateTransactionWithOptions(context.Background(), 42, tt.ut, tt.opts)
+			require.NoError(t, err)
+			assert.True(t, got.Updated)
+		})
+	}
+}
+
+func TestNullableRoundTrip(t *testing.T) {
+	var got struct {
+		Unset   Nullable[int64] `json:"unset,omitzero"`
+		Cleared Nullable[int64] `json:"cleared,omitzero"`
+		Value   Nullable[int64] `json:"value,omitzero"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"cleared": null, "value": 7}`), &got))
+
+	assert.True(t, got.Unset.IsZero())
+	assert.True(t, got.Cleared.IsNull())
+	v, ok := got.Value.Get()
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), v)
+
+	b, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"cleared": null, "value": 7}`, string(b))
+}
@@ -14,20 +14,46 @@ type User struct {
 	BudgetName      string `json:"budget_name"`
 	PrimaryCurrency string `json:"primary_currency"`
 	APIKeyLabel     string `json:"api_key_label"`
+
+	// Extra holds the members of the API's JSON that have no field above.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *User) UnmarshalJSON(data []byte) error {
+	type plain User
+	extra, err := unmarshalExtra(data, (*plain)(u))
+	u.Extra = extra
+
+	return err
 }
 
-// GetUser retrieves information about the currently authenticated user.
+// MarshalJSON implements json.Marshaler.
+func (u User) MarshalJSON() ([]byte, error) {
+	type plain User
+
+	return marshalExtra(plain(u), u.Extra)
+}
+
+// Get retrieves information about the currently authenticated user.
 // It returns details such as user name, email, ID, and account preferences.
-func (c *Client) GetUser(ctx context.Context) (*User, error) {
-	body, err := c.Get(ctx, "/v1/me", nil)
+func (s *userService) Get(ctx context.Context) (*User, error) {
+	body, err := s.c.Get(ctx, "/v1/me", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &User{}
-	if err := json.NewDecoder(body).Decode(resp); err != nil {
+	if err := s.c.decode(ctx, "/v1/me", body, resp); err != nil {
 		return nil, err
 	}
 
 	return resp, nil
 }
+
+// GetUser retrieves the currently authenticated user.
+//
+// Deprecated: Use Client.User.Get.
+func (c *Client) GetUser(ctx context.Context) (*User, error) {
+	return c.UserService().Get(ctx)
+}